@@ -0,0 +1,149 @@
+package bedrock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/milosgajdos/go-embeddings/client"
+)
+
+// BatchRequest embeds many inputs with models
+// which accept a limited number of inputs per invocation.
+type BatchRequest struct {
+	// Inputs are the texts to embed.
+	Inputs []string
+	// Request sets the parameters shared by all inputs.
+	// Its InputText is replaced by every input.
+	// NOTE: Texts must not be set, use Inputs instead.
+	Request
+}
+
+// BatchResponse is the batch embeddings response.
+// Responses and Errors are in the order of inputs.
+type BatchResponse struct {
+	// Responses contains nil for failed inputs.
+	Responses []*Response
+	// Errors contains nil for embedded inputs.
+	Errors []error
+	// InputTextTokenCount is the sum of
+	// the token counts of all the responses.
+	InputTextTokenCount int
+}
+
+// Err returns the per-input errors joined
+// into a single error or nil if there are none.
+func (b *BatchResponse) Err() error {
+	return client.JoinErrors(b.Errors)
+}
+
+// ToEmbeddings converts the API responses,
+// into a slice of embeddings and returns it.
+// It returns error if any of the inputs failed.
+func (b *BatchResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	if err := b.Err(); err != nil {
+		return nil, err
+	}
+	embs := make([]*embeddings.Embedding, 0, len(b.Responses))
+	for _, resp := range b.Responses {
+		e, err := resp.ToEmbeddings()
+		if err != nil {
+			return nil, err
+		}
+		embs = append(embs, e...)
+	}
+	return embs, nil
+}
+
+// BatchEmbeddings embeds every input with a separate model invocation.
+// Cohere models embed the inputs in batches of at most CohereMaxTexts
+// texts per invocation instead; the inputs of a failed batch all fail
+// with the batch error. The invocations are dispatched concurrently
+// by at most Concurrency workers and are rate limited by the client Limiter.
+// Failed inputs do not fail the batch: their errors are returned in BatchResponse.
+// It returns ErrUnsupportedParam if the request sets Texts.
+func (c *Client) BatchEmbeddings(ctx context.Context, batchReq *BatchRequest) (*BatchResponse, error) {
+	if len(batchReq.Inputs) == 0 {
+		return nil, ErrMissingInput
+	}
+	if len(batchReq.Texts) > 0 {
+		return nil, fmt.Errorf("%w: texts, use inputs", ErrUnsupportedParam)
+	}
+
+	var (
+		resps []*Response
+		errs  []error
+	)
+	if ModelFamily(c.opts.ModelID) == CohereFamily {
+		resps, errs = c.cohereBatch(ctx, batchReq)
+	} else {
+		resps, errs = client.FanOut(ctx, c.opts.Concurrency, batchReq.Inputs, func(ctx context.Context, input string) (*Response, error) {
+			req := batchReq.Request
+			req.InputText = input
+			return c.Embeddings(ctx, &req)
+		})
+	}
+
+	batchResp := &BatchResponse{
+		Responses: resps,
+		Errors:    errs,
+	}
+	for _, resp := range resps {
+		if resp != nil {
+			batchResp.InputTextTokenCount += resp.InputTextTokenCount
+		}
+	}
+
+	return batchResp, nil
+}
+
+// cohereBatch embeds the inputs in batches of at most CohereMaxTexts texts.
+// It returns a single embedding response and error per input.
+func (c *Client) cohereBatch(ctx context.Context, batchReq *BatchRequest) ([]*Response, []error) {
+	batches := make([][]string, 0, (len(batchReq.Inputs)+CohereMaxTexts-1)/CohereMaxTexts)
+	for i := 0; i < len(batchReq.Inputs); i += CohereMaxTexts {
+		end := min(i+CohereMaxTexts, len(batchReq.Inputs))
+		batches = append(batches, batchReq.Inputs[i:end])
+	}
+
+	batchResps, batchErrs := client.FanOut(ctx, c.opts.Concurrency, batches, func(ctx context.Context, texts []string) (*Response, error) {
+		req := batchReq.Request
+		req.InputText = ""
+		req.Texts = texts
+		resp, err := c.Embeddings(ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Embeddings) != len(texts) {
+			return nil, fmt.Errorf("%w: %d embeddings for %d texts", ErrInValidData, len(resp.Embeddings), len(texts))
+		}
+		return resp, nil
+	})
+
+	resps := make([]*Response, 0, len(batchReq.Inputs))
+	errs := make([]error, 0, len(batchReq.Inputs))
+	for i, texts := range batches {
+		for j := range texts {
+			if batchErrs[i] != nil {
+				resps = append(resps, nil)
+				errs = append(errs, batchErrs[i])
+				continue
+			}
+			resps = append(resps, &Response{
+				Embeddings: batchResps[i].Embeddings[j : j+1],
+			})
+			errs = append(errs, nil)
+		}
+	}
+	return resps, errs
+}
+
+// BatchEmbed returns embeddings for every input in BatchRequest.
+// It returns error if any of the inputs failed.
+func (c *Client) BatchEmbed(ctx context.Context, batchReq *BatchRequest) ([]*embeddings.Embedding, error) {
+	resp, err := c.BatchEmbeddings(ctx, batchReq)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ToEmbeddings()
+}
@@ -0,0 +1,165 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// funcClient is a fake Bedrock API client safe for concurrent use.
+type funcClient func(*bedrockruntime.InvokeModelInput) (*bedrockruntime.InvokeModelOutput, error)
+
+func (f funcClient) InvokeModel(_ context.Context, in *bedrockruntime.InvokeModelInput, _ ...func(*bedrockruntime.Options)) (*bedrockruntime.InvokeModelOutput, error) {
+	return f(in)
+}
+
+// countLimiter counts the Wait calls.
+type countLimiter struct {
+	calls atomic.Int32
+}
+
+func (l *countLimiter) Wait(context.Context) error {
+	l.calls.Add(1)
+	return nil
+}
+
+// echoClient embeds every input text as a vector of its length.
+func echoClient(t *testing.T) funcClient {
+	return func(in *bedrockruntime.InvokeModelInput) (*bedrockruntime.InvokeModelOutput, error) {
+		var req Request
+		assert.NoError(t, json.Unmarshal(in.Body, &req))
+		if req.InputText == "bad" {
+			return nil, &types.ValidationException{Message: aws.String("bad input")}
+		}
+		body := fmt.Sprintf(`{"embedding":[%d],"inputTextTokenCount":%d}`, len(req.InputText), len(req.InputText))
+		return &bedrockruntime.InvokeModelOutput{Body: []byte(body)}, nil
+	}
+}
+
+func TestBatchEmbeddings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("order", func(t *testing.T) {
+		t.Parallel()
+		l := &countLimiter{}
+		c, err := NewClient(
+			WithModelID(TitanTextV2.String()),
+			WithBedrockClient(echoClient(t)),
+			WithLimiter(l),
+			WithConcurrency(2),
+		)
+		assert.NoError(t, err)
+
+		inputs := []string{"a", "bbb", "cc", "dddd"}
+		resp, err := c.BatchEmbeddings(context.Background(), &BatchRequest{Inputs: inputs, Request: Request{Dimensions: 256}})
+		assert.NoError(t, err)
+		assert.NoError(t, resp.Err())
+		assert.Equal(t, 10, resp.InputTextTokenCount)
+		assert.Equal(t, int32(len(inputs)), l.calls.Load())
+
+		embs, err := resp.ToEmbeddings()
+		assert.NoError(t, err)
+		assert.Len(t, embs, len(inputs))
+		for i, input := range inputs {
+			assert.Equal(t, []float64{float64(len(input))}, embs[i].Vector)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		c, err := NewClient(WithModelID(TitanTextV2.String()), WithBedrockClient(echoClient(t)))
+		assert.NoError(t, err)
+
+		resp, err := c.BatchEmbeddings(context.Background(), &BatchRequest{Inputs: []string{"a", "bad", "cc"}})
+		assert.NoError(t, err)
+		assert.Nil(t, resp.Responses[1])
+		assert.ErrorIs(t, resp.Errors[1], ErrValidation)
+		assert.NoError(t, resp.Errors[0])
+		assert.NoError(t, resp.Errors[2])
+		assert.Equal(t, 3, resp.InputTextTokenCount)
+		assert.ErrorIs(t, resp.Err(), ErrValidation)
+
+		_, err = c.BatchEmbed(context.Background(), &BatchRequest{Inputs: []string{"a", "bad"}})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("cohere", func(t *testing.T) {
+		t.Parallel()
+		var calls atomic.Int32
+		cohere := funcClient(func(in *bedrockruntime.InvokeModelInput) (*bedrockruntime.InvokeModelOutput, error) {
+			calls.Add(1)
+			var req struct {
+				Texts     []string `json:"texts"`
+				InputType string   `json:"input_type"`
+			}
+			assert.NoError(t, json.Unmarshal(in.Body, &req))
+			assert.LessOrEqual(t, len(req.Texts), CohereMaxTexts)
+			assert.Equal(t, SearchDocInput.String(), req.InputType)
+			embs := make([]string, 0, len(req.Texts))
+			for _, text := range req.Texts {
+				if text == "bad" {
+					return nil, &types.ValidationException{Message: aws.String("bad input")}
+				}
+				embs = append(embs, fmt.Sprintf("[%d]", len(text)))
+			}
+			body := fmt.Sprintf(`{"id":"1","embeddings":[%s],"response_type":"embeddings_floats"}`, strings.Join(embs, ","))
+			return &bedrockruntime.InvokeModelOutput{Body: []byte(body)}, nil
+		})
+		c, err := NewClient(WithModelID(CohereEnglishV3.String()), WithBedrockClient(cohere))
+		assert.NoError(t, err)
+
+		inputs := make([]string, 0, CohereMaxTexts+4)
+		for i := range CohereMaxTexts + 4 {
+			inputs = append(inputs, strings.Repeat("a", i%5+1))
+		}
+		embs, err := c.BatchEmbed(context.Background(), &BatchRequest{
+			Inputs:  inputs,
+			Request: Request{InputType: SearchDocInput},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Len(t, embs, len(inputs))
+		for i, input := range inputs {
+			assert.Equal(t, []float64{float64(len(input))}, embs[i].Vector)
+		}
+
+		inputs[CohereMaxTexts+1] = "bad"
+		resp, err := c.BatchEmbeddings(context.Background(), &BatchRequest{
+			Inputs:  inputs,
+			Request: Request{InputType: SearchDocInput},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Responses, len(inputs))
+		assert.NoError(t, resp.Errors[CohereMaxTexts-1])
+		for i := CohereMaxTexts; i < len(inputs); i++ {
+			assert.Nil(t, resp.Responses[i])
+			assert.ErrorIs(t, resp.Errors[i], ErrValidation)
+		}
+
+		_, err = c.BatchEmbeddings(context.Background(), &BatchRequest{
+			Inputs: []string{"a"},
+			Request: Request{
+				Texts:     []string{"foo", "bar"},
+				InputType: SearchDocInput,
+			},
+		})
+		assert.ErrorIs(t, err, ErrUnsupportedParam)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		c, err := NewClient(WithModelID(TitanTextV2.String()), WithBedrockClient(echoClient(t)))
+		assert.NoError(t, err)
+
+		_, err = c.BatchEmbeddings(context.Background(), &BatchRequest{})
+		assert.ErrorIs(t, err, ErrMissingInput)
+	})
+}
@@ -6,9 +6,56 @@ type Model string
 const (
 	TitanTextV1 Model = "amazon.titan-embed-text-v1"
 	TitanTextV2 Model = "amazon.titan-embed-text-v2:0"
+	// TitanImageV1 is a multimodal embedding model.
+	TitanImageV1 Model = "amazon.titan-embed-image-v1"
+	// Cohere* are Cohere embedding models hosted by Bedrock.
+	CohereEnglishV3   Model = "cohere.embed-english-v3"
+	CohereMultiLingV3 Model = "cohere.embed-multilingual-v3"
 )
 
 // String implements stringer.
 func (m Model) String() string {
 	return string(m)
 }
+
+// EmbeddingType is the type of the output embeddings.
+type EmbeddingType string
+
+const (
+	FloatEmbedding  EmbeddingType = "float"
+	BinaryEmbedding EmbeddingType = "binary"
+)
+
+// String implements stringer.
+func (e EmbeddingType) String() string {
+	return string(e)
+}
+
+// InputType is Cohere embedding input type.
+type InputType string
+
+const (
+	SearchDocInput      InputType = "search_document"
+	SearchQueryInput    InputType = "search_query"
+	ClassificationInput InputType = "classification"
+	ClusteringInput     InputType = "clustering"
+)
+
+// String implements stringer.
+func (i InputType) String() string {
+	return string(i)
+}
+
+// Truncate controls Cohere input truncating.
+type Truncate string
+
+const (
+	StartTrunc Truncate = "START"
+	EndTrunc   Truncate = "END"
+	NoneTrunc  Truncate = "NONE"
+)
+
+// String implements stringer.
+func (t Truncate) String() string {
+	return string(t)
+}
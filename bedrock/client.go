@@ -2,11 +2,12 @@ package bedrock
 
 import (
 	"context"
-	"log"
 	"os"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/milosgajdos/go-embeddings/client"
 )
 
 const (
@@ -14,14 +15,27 @@ const (
 	DefaultRegion = "us-east-1"
 )
 
+// InvokeModelAPI invokes Bedrock models.
+// It's implemented by *bedrockruntime.Client.
+type InvokeModelAPI interface {
+	InvokeModel(ctx context.Context, params *bedrockruntime.InvokeModelInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.InvokeModelOutput, error)
+}
+
+// Client is AWS Bedrock embeddings client.
 type Client struct {
 	opts Options
 }
 
+// Options are client options
 type Options struct {
 	Region  string
 	ModelID string
-	Client  *bedrockruntime.Client
+	Client  InvokeModelAPI
+	// Limiter rate limits the model invocations.
+	Limiter client.Limiter
+	// Concurrency is the maximum number
+	// of concurrent batch model invocations.
+	Concurrency int
 }
 
 // Option is functional option.
@@ -30,10 +44,12 @@ type Option func(*Options)
 // NewClient creates a new AWS Bedrock HTTP API client and returns it.
 // By default it reads the default AWS evnironment variables.
 // and constructs the AWS API client.
-func NewClient(opts ...Option) *Client {
+// It returns error if the default AWS config fails to load.
+func NewClient(opts ...Option) (*Client, error) {
 	options := Options{
-		Region:  os.Getenv("AWS_REGION"),
-		ModelID: os.Getenv("AWS_BEDROCK_MODEL_ID"),
+		Region:      os.Getenv("AWS_REGION"),
+		ModelID:     os.Getenv("AWS_BEDROCK_MODEL_ID"),
+		Concurrency: client.DefaultConcurrency,
 	}
 
 	for _, apply := range opts {
@@ -47,7 +63,7 @@ func NewClient(opts ...Option) *Client {
 	if options.Client == nil {
 		cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(options.Region))
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 
 		options.Client = bedrockruntime.NewFromConfig(cfg)
@@ -55,7 +71,12 @@ func NewClient(opts ...Option) *Client {
 
 	return &Client{
 		opts: options,
-	}
+	}, nil
+}
+
+// NewEmbedder creates a client that implements embeddings.Embedder
+func NewEmbedder(opts ...Option) (embeddings.Embedder[*Request], error) {
+	return NewClient(opts...)
 }
 
 // WithRegion sets AWS region.
@@ -73,8 +94,25 @@ func WithModelID(id string) Option {
 }
 
 // WithBedrockClient sets Bedrock API client.
-func WithBedrockClient(client *bedrockruntime.Client) Option {
+// It accepts *bedrockruntime.Client or any
+// other implementation of InvokeModelAPI.
+func WithBedrockClient(client InvokeModelAPI) Option {
 	return func(o *Options) {
 		o.Client = client
 	}
 }
+
+// WithLimiter sets the model invocation rate limiter.
+func WithLimiter(l client.Limiter) Option {
+	return func(o *Options) {
+		o.Limiter = l
+	}
+}
+
+// WithConcurrency sets the maximum number
+// of concurrent batch model invocations.
+func WithConcurrency(n int) Option {
+	return func(o *Options) {
+		o.Concurrency = n
+	}
+}
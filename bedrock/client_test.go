@@ -18,36 +18,47 @@ func TestClient(t *testing.T) {
 
 	t.Run("Region", func(t *testing.T) {
 		t.Parallel()
-		c := NewClient()
+		c, err := NewClient()
+		assert.NoError(t, err)
 		assert.Equal(t, c.opts.Region, DefaultRegion)
 
 		testVal := "us-west-1"
-		c = NewClient(WithRegion(testVal))
+		c, err = NewClient(WithRegion(testVal))
+		assert.NoError(t, err)
 		assert.Equal(t, c.opts.Region, testVal)
 	})
 
 	t.Run("ModelID", func(t *testing.T) {
 		t.Parallel()
-		c := NewClient()
+		c, err := NewClient()
+		assert.NoError(t, err)
 		assert.Equal(t, c.opts.ModelID, "")
 
-		c = NewClient(WithModelID(bedrockModelID))
+		c, err = NewClient(WithModelID(bedrockModelID))
+		assert.NoError(t, err)
 		assert.Equal(t, c.opts.ModelID, bedrockModelID)
 	})
 
 	t.Run("BedrockClient", func(t *testing.T) {
 		t.Parallel()
-		c := NewClient()
-		assert.NotNil(t, c.opts.Client)
-		assert.Equal(t, c.opts.Client.Options().Region, DefaultRegion)
+		c, err := NewClient()
+		assert.NoError(t, err)
+		bc, ok := c.opts.Client.(*bedrockruntime.Client)
+		assert.True(t, ok)
+		assert.Equal(t, bc.Options().Region, DefaultRegion)
 
 		testVal := "us-west-1"
 		cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(testVal))
 		assert.NoError(t, err)
-		bc := bedrockruntime.NewFromConfig(cfg)
+		bc = bedrockruntime.NewFromConfig(cfg)
+
+		c, err = NewClient(WithBedrockClient(bc))
+		assert.NoError(t, err)
+		assert.Equal(t, c.opts.Client, bc)
 
-		c = NewClient(WithBedrockClient(bc))
-		assert.NotNil(t, c.opts.Client)
-		assert.Equal(t, c.opts.Client.Options().Region, testVal)
+		m := &mockClient{}
+		c, err = NewClient(WithBedrockClient(m))
+		assert.NoError(t, err)
+		assert.Equal(t, c.opts.Client, m)
 	})
 }
@@ -2,36 +2,198 @@ package bedrock
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
 	"github.com/milosgajdos/go-embeddings"
 )
 
+// Request is embeddings request.
+// It is encoded using the schema of the family of the client model.
+// Its JSON encoding is the Titan text embeddings request.
+// https://docs.aws.amazon.com/bedrock/latest/userguide/model-parameters-titan-embed-text.html
 type Request struct {
+	// InputText is the text to embed.
+	// It's embedded alongside InputImage by TitanImageV1.
 	InputText string `json:"inputText"`
+	// Dimensions of the output embedding.
+	// TitanTextV2: 256, 512 or 1024 (default).
+	// TitanImageV1: 256, 384 or 1024 (default).
+	Dimensions int `json:"dimensions,omitempty"`
+	// NOTE: the following parameters are only supported by TitanTextV2.
+	// Normalize the output embedding. Defaults to true.
+	Normalize *bool `json:"normalize,omitempty"`
+	// EmbeddingTypes of the output embeddings. Defaults to float.
+	EmbeddingTypes []EmbeddingType `json:"embeddingTypes,omitempty"`
+	// InputImage is the raw image to embed.
+	// NOTE: only supported by TitanImageV1.
+	InputImage []byte `json:"-"`
+	// NOTE: the following parameters are only supported by Cohere models.
+	// Texts to embed. If empty, InputText is embedded.
+	Texts     []string  `json:"-"`
+	InputType InputType `json:"-"`
+	Truncate  Truncate  `json:"-"`
 }
 
+// texts returns the texts to embed with Cohere models.
+func (r *Request) texts() []string {
+	if len(r.Texts) == 0 && r.InputText != "" {
+		return []string{r.InputText}
+	}
+	return r.Texts
+}
+
+// Validate validates the request parameters against the given model.
+// Unknown models are only validated against their family schema.
+func (r *Request) Validate(model Model) error {
+	family := ModelFamily(model.String())
+
+	if family != TitanTextFamily && (r.Normalize != nil || len(r.EmbeddingTypes) > 0) {
+		return fmt.Errorf("%w: normalize and embeddingTypes require %s", ErrUnsupportedParam, TitanTextV2)
+	}
+	if family != TitanImageFamily && len(r.InputImage) > 0 {
+		return fmt.Errorf("%w: inputImage requires %s", ErrUnsupportedParam, TitanImageV1)
+	}
+	if family != CohereFamily && (len(r.Texts) > 0 || r.InputType != "" || r.Truncate != "") {
+		return fmt.Errorf("%w: texts, input_type and truncate require Cohere models", ErrUnsupportedParam)
+	}
+
+	switch family {
+	case TitanImageFamily:
+		switch r.Dimensions {
+		case 0, 256, 384, 1024:
+		default:
+			return fmt.Errorf("%w: %d, %s supports 256, 384 or 1024", ErrInvalidDimensions, r.Dimensions, model)
+		}
+		if r.InputText == "" && len(r.InputImage) == 0 {
+			return fmt.Errorf("%w: inputText or inputImage required", ErrMissingInput)
+		}
+	case CohereFamily:
+		if r.Dimensions != 0 {
+			return fmt.Errorf("%w: dimensions are not supported by %s", ErrUnsupportedParam, model)
+		}
+		texts := r.texts()
+		if len(texts) == 0 {
+			return fmt.Errorf("%w: texts required", ErrMissingInput)
+		}
+		if len(texts) > CohereMaxTexts {
+			return fmt.Errorf("%w: %d texts, at most %d allowed", ErrTooManyTexts, len(texts), CohereMaxTexts)
+		}
+		if r.InputType == "" {
+			return fmt.Errorf("%w: input_type required", ErrMissingInput)
+		}
+	}
+
+	switch model {
+	case TitanTextV1:
+		if r.Dimensions != 0 || r.Normalize != nil || len(r.EmbeddingTypes) > 0 {
+			return fmt.Errorf("%w: dimensions, normalize and embeddingTypes require %s", ErrUnsupportedParam, TitanTextV2)
+		}
+	case TitanTextV2:
+		switch r.Dimensions {
+		case 0, 256, 512, 1024:
+		default:
+			return fmt.Errorf("%w: %d, %s supports 256, 512 or 1024", ErrInvalidDimensions, r.Dimensions, model)
+		}
+		for _, t := range r.EmbeddingTypes {
+			if t != FloatEmbedding && t != BinaryEmbedding {
+				return fmt.Errorf("%w: %s", ErrUnsupportedEmbeddingType, t)
+			}
+		}
+	}
+
+	return nil
+}
+
+// EmbeddingsByType stores the embeddings by their type.
+type EmbeddingsByType struct {
+	Float  []float64 `json:"float,omitempty"`
+	Binary []int8    `json:"binary,omitempty"`
+}
+
+// Response is embeddings response.
+// Its JSON encoding is the Titan embeddings response.
 type Response struct {
-	Embedding           []float64 `json:"embedding"`
-	InputTextTokenCount int       `json:"inputTextTokenCount"`
+	Embedding           []float64         `json:"embedding"`
+	InputTextTokenCount int               `json:"inputTextTokenCount"`
+	EmbeddingsByType    *EmbeddingsByType `json:"embeddingsByType,omitempty"`
+	// Embeddings are returned by Cohere models.
+	Embeddings [][]float64 `json:"-"`
 }
 
+// ToEmbeddings converts the API response,
+// into a slice of embeddings and returns it.
+// It returns ErrMissingFloat if only the binary
+// embeddings were requested, see ToBinaryEmbeddings.
 func (e *Response) ToEmbeddings() ([]*embeddings.Embedding, error) {
-	vals := make([]float64, len(e.Embedding))
-	copy(vals, e.Embedding)
+	if len(e.Embeddings) > 0 {
+		embs := make([]*embeddings.Embedding, 0, len(e.Embeddings))
+		for _, vals := range e.Embeddings {
+			floats := make([]float64, len(vals))
+			copy(floats, vals)
+			embs = append(embs, &embeddings.Embedding{
+				Vector: floats,
+			})
+		}
+		return embs, nil
+	}
+
+	vals := e.Embedding
+	if len(vals) == 0 && e.EmbeddingsByType != nil {
+		if e.EmbeddingsByType.Float == nil {
+			return nil, ErrMissingFloat
+		}
+		vals = e.EmbeddingsByType.Float
+	}
+	floats := make([]float64, len(vals))
+	copy(floats, vals)
 	return []*embeddings.Embedding{
-		{Vector: vals},
+		{Vector: floats},
+	}, nil
+}
+
+// ToBinaryEmbeddings returns the binary embeddings
+// packed into bits. It returns ErrMissingBinary
+// if the binary embeddings were not requested.
+func (e *Response) ToBinaryEmbeddings() ([]*embeddings.BinaryEmbedding, error) {
+	if e.EmbeddingsByType == nil || e.EmbeddingsByType.Binary == nil {
+		return nil, ErrMissingBinary
+	}
+	return []*embeddings.BinaryEmbedding{
+		embeddings.NewBinaryEmbedding(e.EmbeddingsByType.Binary),
 	}, nil
 }
 
 // Embed returns embeddings for every object in EmbeddingRequest.
 func (c *Client) Embed(ctx context.Context, embReq *Request) ([]*embeddings.Embedding, error) {
-	payload, err := json.Marshal(embReq)
+	embs, err := c.Embeddings(ctx, embReq)
 	if err != nil {
 		return nil, err
 	}
+	return embs.ToEmbeddings()
+}
+
+// Embeddings validates the request against the client model,
+// fetches the embeddings and returns the API response.
+// The request and response schema is picked from the client model ID.
+func (c *Client) Embeddings(ctx context.Context, embReq *Request) (*Response, error) {
+	if err := embReq.Validate(Model(c.opts.ModelID)); err != nil {
+		return nil, err
+	}
+
+	family := ModelFamily(c.opts.ModelID)
+
+	payload, err := encodeRequest(family, embReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.opts.Limiter != nil {
+		if err := c.opts.Limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
 
 	resp, err := c.opts.Client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
 		Body:        payload,
@@ -39,13 +201,8 @@ func (c *Client) Embed(ctx context.Context, embReq *Request) ([]*embeddings.Embe
 		ContentType: aws.String("application/json"),
 	})
 	if err != nil {
-		return nil, err
-	}
-
-	var embs Response
-	if err = json.Unmarshal(resp.Body, &embs); err != nil {
-		return nil, nil
+		return nil, toError(err)
 	}
 
-	return embs.ToEmbeddings()
+	return decodeResponse(family, resp.Body)
 }
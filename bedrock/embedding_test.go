@@ -0,0 +1,202 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockClient is a fake Bedrock API client.
+type mockClient struct {
+	body  []byte
+	err   error
+	input *bedrockruntime.InvokeModelInput
+}
+
+func (m *mockClient) InvokeModel(_ context.Context, in *bedrockruntime.InvokeModelInput, _ ...func(*bedrockruntime.Options)) (*bedrockruntime.InvokeModelOutput, error) {
+	m.input = in
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &bedrockruntime.InvokeModelOutput{
+		Body:        m.body,
+		ContentType: aws.String("application/json"),
+	}, nil
+}
+
+func TestRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	normalize := true
+	testCases := []struct {
+		name  string
+		model Model
+		req   Request
+		err   error
+	}{
+		{name: "v1", model: TitanTextV1, req: Request{InputText: "foo"}},
+		{name: "v1 dims", model: TitanTextV1, req: Request{Dimensions: 256}, err: ErrUnsupportedParam},
+		{name: "v1 normalize", model: TitanTextV1, req: Request{Normalize: &normalize}, err: ErrUnsupportedParam},
+		{name: "v2", model: TitanTextV2, req: Request{Dimensions: 512, Normalize: &normalize, EmbeddingTypes: []EmbeddingType{FloatEmbedding, BinaryEmbedding}}},
+		{name: "v2 dims", model: TitanTextV2, req: Request{Dimensions: 100}, err: ErrInvalidDimensions},
+		{name: "v2 type", model: TitanTextV2, req: Request{EmbeddingTypes: []EmbeddingType{"int8"}}, err: ErrUnsupportedEmbeddingType},
+		{name: "unknown", model: "foo", req: Request{Dimensions: 100}},
+		{name: "v2 image", model: TitanTextV2, req: Request{InputImage: []byte{1}}, err: ErrUnsupportedParam},
+		{name: "v2 input type", model: TitanTextV2, req: Request{InputType: SearchDocInput}, err: ErrUnsupportedParam},
+		{name: "image", model: TitanImageV1, req: Request{InputImage: []byte{1}, Dimensions: 384}},
+		{name: "image dims", model: TitanImageV1, req: Request{InputText: "foo", Dimensions: 512}, err: ErrInvalidDimensions},
+		{name: "image empty", model: TitanImageV1, req: Request{}, err: ErrMissingInput},
+		{name: "image normalize", model: TitanImageV1, req: Request{InputText: "foo", Normalize: &normalize}, err: ErrUnsupportedParam},
+		{name: "cohere", model: CohereEnglishV3, req: Request{Texts: []string{"foo", "bar"}, InputType: SearchQueryInput, Truncate: EndTrunc}},
+		{name: "cohere input text", model: CohereMultiLingV3, req: Request{InputText: "foo", InputType: ClusteringInput}},
+		{name: "cohere input type", model: CohereEnglishV3, req: Request{Texts: []string{"foo"}}, err: ErrMissingInput},
+		{name: "cohere empty", model: CohereEnglishV3, req: Request{InputType: SearchDocInput}, err: ErrMissingInput},
+		{name: "cohere too many", model: CohereEnglishV3, req: Request{Texts: make([]string, CohereMaxTexts+1), InputType: SearchDocInput}, err: ErrTooManyTexts},
+		{name: "cohere dims", model: CohereEnglishV3, req: Request{Texts: []string{"foo"}, InputType: SearchDocInput, Dimensions: 256}, err: ErrUnsupportedParam},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.req.Validate(tc.model)
+			if tc.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("float", func(t *testing.T) {
+		t.Parallel()
+		resp := new(Response)
+		assert.NoError(t, json.Unmarshal([]byte(`{"embedding":[0.5,-0.5],"inputTextTokenCount":2}`), resp))
+		embs, err := resp.ToEmbeddings()
+		assert.NoError(t, err)
+		assert.Equal(t, embs[0].Vector, []float64{0.5, -0.5})
+		_, err = resp.ToBinaryEmbeddings()
+		assert.ErrorIs(t, err, ErrMissingBinary)
+	})
+
+	t.Run("by type", func(t *testing.T) {
+		t.Parallel()
+		resp := new(Response)
+		assert.NoError(t, json.Unmarshal([]byte(`{"embeddingsByType":{"float":[0.5,-0.5],"binary":[1,0,1,1,0,0,0,0,1]},"inputTextTokenCount":2}`), resp))
+		embs, err := resp.ToEmbeddings()
+		assert.NoError(t, err)
+		assert.Equal(t, embs[0].Vector, []float64{0.5, -0.5})
+
+		bins, err := resp.ToBinaryEmbeddings()
+		assert.NoError(t, err)
+		assert.Equal(t, bins[0].Dims, 9)
+		assert.Equal(t, bins[0].Vector, []byte{0xb0, 0x80})
+	})
+
+	t.Run("binary only", func(t *testing.T) {
+		t.Parallel()
+		resp := new(Response)
+		assert.NoError(t, json.Unmarshal([]byte(`{"embeddingsByType":{"binary":[1,0,1,1,0,0,0,0]},"inputTextTokenCount":2}`), resp))
+		_, err := resp.ToEmbeddings()
+		assert.ErrorIs(t, err, ErrMissingFloat)
+
+		bins, err := resp.ToBinaryEmbeddings()
+		assert.NoError(t, err)
+		assert.Equal(t, bins[0].Vector, []byte{0xb0})
+	})
+}
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("titan", func(t *testing.T) {
+		t.Parallel()
+		m := &mockClient{body: []byte(`{"embedding":[0.1,0.2],"inputTextTokenCount":1}`)}
+		c, err := NewClient(WithModelID(TitanTextV2.String()), WithBedrockClient(m))
+		assert.NoError(t, err)
+
+		embs, err := c.Embed(context.Background(), &Request{InputText: "foo", Dimensions: 256})
+		assert.NoError(t, err)
+		assert.Len(t, embs, 1)
+		assert.Equal(t, []float64{0.1, 0.2}, embs[0].Vector)
+		assert.Equal(t, TitanTextV2.String(), aws.ToString(m.input.ModelId))
+		assert.JSONEq(t, `{"inputText":"foo","dimensions":256}`, string(m.input.Body))
+	})
+
+	t.Run("cohere", func(t *testing.T) {
+		t.Parallel()
+		m := &mockClient{body: []byte(`{"id":"1","embeddings":[[0.1],[0.2]],"response_type":"embeddings_floats"}`)}
+		c, err := NewClient(WithModelID(CohereEnglishV3.String()), WithBedrockClient(m))
+		assert.NoError(t, err)
+
+		embs, err := c.Embed(context.Background(), &Request{Texts: []string{"foo", "bar"}, InputType: SearchDocInput})
+		assert.NoError(t, err)
+		assert.Len(t, embs, 2)
+		assert.JSONEq(t, `{"texts":["foo","bar"],"input_type":"search_document"}`, string(m.input.Body))
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		t.Parallel()
+		m := &mockClient{}
+		c, err := NewClient(WithModelID(TitanTextV1.String()), WithBedrockClient(m))
+		assert.NoError(t, err)
+
+		_, err = c.Embed(context.Background(), &Request{InputText: "foo", Dimensions: 256})
+		assert.ErrorIs(t, err, ErrUnsupportedParam)
+		assert.Nil(t, m.input)
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		t.Parallel()
+		m := &mockClient{body: []byte(`{"embedding":`)}
+		c, err := NewClient(WithModelID(TitanTextV1.String()), WithBedrockClient(m))
+		assert.NoError(t, err)
+
+		embs, err := c.Embed(context.Background(), &Request{InputText: "foo"})
+		assert.Error(t, err)
+		assert.Nil(t, embs)
+	})
+
+	testCases := []struct {
+		name string
+		err  error
+		exp  error
+	}{
+		{name: "throttling", err: &types.ThrottlingException{Message: aws.String("slow down")}, exp: ErrThrottled},
+		{name: "quota", err: &types.ServiceQuotaExceededException{Message: aws.String("quota")}, exp: ErrThrottled},
+		{name: "validation", err: &types.ValidationException{Message: aws.String("invalid")}, exp: ErrValidation},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			c, err := NewClient(WithModelID(TitanTextV1.String()), WithBedrockClient(&mockClient{err: tc.err}))
+			assert.NoError(t, err)
+
+			_, err = c.Embed(context.Background(), &Request{InputText: "foo"})
+			assert.ErrorIs(t, err, tc.exp)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	t.Run("other", func(t *testing.T) {
+		t.Parallel()
+		apiErr := errors.New("boom")
+		c, err := NewClient(WithModelID(TitanTextV1.String()), WithBedrockClient(&mockClient{err: apiErr}))
+		assert.NoError(t, err)
+
+		_, err = c.Embed(context.Background(), &Request{InputText: "foo"})
+		assert.Equal(t, apiErr, err)
+	})
+}
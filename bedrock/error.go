@@ -0,0 +1,48 @@
+package bedrock
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+)
+
+var (
+	// ErrInvalidDimensions is returned when the requested dimensions are not supported by the model.
+	ErrInvalidDimensions = errors.New("invalid dimensions")
+	// ErrUnsupportedParam is returned when the request parameter is not supported by the model.
+	ErrUnsupportedParam = errors.New("unsupported parameter")
+	// ErrUnsupportedEmbeddingType is returned when the requested embedding type is not supported by the model.
+	ErrUnsupportedEmbeddingType = errors.New("unsupported embedding type")
+	// ErrMissingInput is returned when the request is missing the required input.
+	ErrMissingInput = errors.New("missing input")
+	// ErrTooManyTexts is returned when the request exceeds the model text limit.
+	ErrTooManyTexts = errors.New("too many texts")
+	// ErrInValidData is returned when the response does not match the request.
+	ErrInValidData = errors.New("invalid data")
+	// ErrMissingFloat is returned when the response only contains binary embeddings.
+	ErrMissingFloat = errors.New("missing float embeddings, use ToBinaryEmbeddings")
+	// ErrMissingBinary is returned when the response does not contain binary embeddings.
+	ErrMissingBinary = errors.New("missing binary embeddings")
+	// ErrThrottled is returned when the Bedrock API throttles the request.
+	ErrThrottled = errors.New("request throttled")
+	// ErrValidation is returned when the Bedrock API rejects the request as invalid.
+	ErrValidation = errors.New("request validation failed")
+)
+
+// toError maps the Bedrock API errors to the package errors.
+// The original error is preserved in the error chain.
+func toError(err error) error {
+	var (
+		throttling *types.ThrottlingException
+		quota      *types.ServiceQuotaExceededException
+		validation *types.ValidationException
+	)
+	switch {
+	case errors.As(err, &throttling), errors.As(err, &quota):
+		return fmt.Errorf("%w: %w", ErrThrottled, err)
+	case errors.As(err, &validation):
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+package bedrock
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+const (
+	// CohereMaxTexts is the maximum number
+	// of texts in a single Cohere request.
+	CohereMaxTexts = 96
+)
+
+// Family is a model family.
+// Models of the same family share the API schema.
+type Family string
+
+const (
+	TitanTextFamily  Family = "titan-text"
+	TitanImageFamily Family = "titan-image"
+	CohereFamily     Family = "cohere"
+)
+
+// String implements stringer.
+func (f Family) String() string {
+	return string(f)
+}
+
+// ModelFamily returns the family of the model with the given ID.
+// The ID can also be an inference profile ID or a model ARN.
+// It returns TitanTextFamily if the family can't be determined.
+func ModelFamily(modelID string) Family {
+	switch {
+	case strings.Contains(modelID, "amazon.titan-embed-image"):
+		return TitanImageFamily
+	case strings.Contains(modelID, "cohere.embed"):
+		return CohereFamily
+	}
+	return TitanTextFamily
+}
+
+// titanImageRequest is Titan multimodal embeddings request.
+// https://docs.aws.amazon.com/bedrock/latest/userguide/model-parameters-titan-embed-mm.html
+type titanImageRequest struct {
+	InputText       string           `json:"inputText,omitempty"`
+	InputImage      string           `json:"inputImage,omitempty"`
+	EmbeddingConfig *embeddingConfig `json:"embeddingConfig,omitempty"`
+}
+
+type embeddingConfig struct {
+	OutputEmbeddingLength int `json:"outputEmbeddingLength"`
+}
+
+// cohereRequest is Cohere embeddings request.
+// https://docs.aws.amazon.com/bedrock/latest/userguide/model-parameters-embed.html
+type cohereRequest struct {
+	Texts     []string  `json:"texts"`
+	InputType InputType `json:"input_type"`
+	Truncate  Truncate  `json:"truncate,omitempty"`
+}
+
+// cohereResponse is Cohere embeddings response.
+type cohereResponse struct {
+	ID           string      `json:"id"`
+	Embeddings   [][]float64 `json:"embeddings"`
+	Texts        []string    `json:"texts"`
+	ResponseType string      `json:"response_type"`
+}
+
+// encodeRequest encodes the request using the schema of the given model family.
+func encodeRequest(family Family, r *Request) ([]byte, error) {
+	switch family {
+	case TitanImageFamily:
+		req := titanImageRequest{
+			InputText: r.InputText,
+		}
+		if len(r.InputImage) > 0 {
+			req.InputImage = base64.StdEncoding.EncodeToString(r.InputImage)
+		}
+		if r.Dimensions != 0 {
+			req.EmbeddingConfig = &embeddingConfig{
+				OutputEmbeddingLength: r.Dimensions,
+			}
+		}
+		return json.Marshal(req)
+	case CohereFamily:
+		return json.Marshal(cohereRequest{
+			Texts:     r.texts(),
+			InputType: r.InputType,
+			Truncate:  r.Truncate,
+		})
+	}
+	return json.Marshal(r)
+}
+
+// decodeResponse decodes the response using the schema of the given model family.
+func decodeResponse(family Family, body []byte) (*Response, error) {
+	if family == CohereFamily {
+		var resp cohereResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, err
+		}
+		return &Response{
+			Embeddings: resp.Embeddings,
+		}, nil
+	}
+
+	// NOTE: Titan text and image responses share the schema
+	resp := new(Response)
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,115 @@
+package bedrock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelFamily(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		modelID string
+		family  Family
+	}{
+		{modelID: TitanTextV1.String(), family: TitanTextFamily},
+		{modelID: TitanTextV2.String(), family: TitanTextFamily},
+		{modelID: TitanImageV1.String(), family: TitanImageFamily},
+		{modelID: CohereEnglishV3.String(), family: CohereFamily},
+		{modelID: "arn:aws:bedrock:us-east-1::foundation-model/cohere.embed-multilingual-v3", family: CohereFamily},
+		{modelID: "foo", family: TitanTextFamily},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.modelID, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.family, ModelFamily(tc.modelID))
+		})
+	}
+}
+
+func TestEncodeRequest(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		family Family
+		req    *Request
+		exp    string
+	}{
+		{
+			name:   "titan text",
+			family: TitanTextFamily,
+			req:    &Request{InputText: "foo", Dimensions: 256},
+			exp:    `{"inputText":"foo","dimensions":256}`,
+		},
+		{
+			name:   "titan image",
+			family: TitanImageFamily,
+			req:    &Request{InputText: "foo", InputImage: []byte("bar"), Dimensions: 384},
+			exp:    `{"inputText":"foo","inputImage":"YmFy","embeddingConfig":{"outputEmbeddingLength":384}}`,
+		},
+		{
+			name:   "titan image only",
+			family: TitanImageFamily,
+			req:    &Request{InputImage: []byte("bar")},
+			exp:    `{"inputImage":"YmFy"}`,
+		},
+		{
+			name:   "cohere",
+			family: CohereFamily,
+			req:    &Request{Texts: []string{"foo", "bar"}, InputType: SearchDocInput, Truncate: EndTrunc},
+			exp:    `{"texts":["foo","bar"],"input_type":"search_document","truncate":"END"}`,
+		},
+		{
+			name:   "cohere input text",
+			family: CohereFamily,
+			req:    &Request{InputText: "foo", InputType: SearchQueryInput},
+			exp:    `{"texts":["foo"],"input_type":"search_query"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			payload, err := encodeRequest(tc.family, tc.req)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.exp, string(payload))
+		})
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cohere", func(t *testing.T) {
+		t.Parallel()
+		body := `{"id":"1","embeddings":[[0.1,0.2],[0.3,0.4]],"texts":["foo","bar"],"response_type":"embeddings_floats"}`
+		resp, err := decodeResponse(CohereFamily, []byte(body))
+		assert.NoError(t, err)
+		embs, err := resp.ToEmbeddings()
+		assert.NoError(t, err)
+		assert.Len(t, embs, 2)
+		assert.Equal(t, []float64{0.3, 0.4}, embs[1].Vector)
+	})
+
+	t.Run("titan image", func(t *testing.T) {
+		t.Parallel()
+		body := `{"embedding":[0.1,0.2],"inputTextTokenCount":1}`
+		resp, err := decodeResponse(TitanImageFamily, []byte(body))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.InputTextTokenCount)
+		embs, err := resp.ToEmbeddings()
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{0.1, 0.2}, embs[0].Vector)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		_, err := decodeResponse(CohereFamily, []byte(`{`))
+		assert.Error(t, err)
+	})
+}
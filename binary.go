@@ -0,0 +1,59 @@
+package embeddings
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var (
+	// ErrDimMismatch is returned when embeddings have different dimensions.
+	ErrDimMismatch = errors.New("embedding dimensions mismatch")
+)
+
+// BinaryEmbedding is a bit-packed binary vector embedding.
+// Every byte of Vector packs 8 dimensions, the most significant bit first.
+type BinaryEmbedding struct {
+	Vector []byte `json:"vector"`
+	Dims   int    `json:"dims"`
+}
+
+// NewBinaryEmbedding packs the given values into a binary embedding
+// and returns it. Positive values are packed as 1, the rest as 0.
+func NewBinaryEmbedding[T ~int | ~int8 | ~float32 | ~float64](vals []T) *BinaryEmbedding {
+	packed := make([]byte, (len(vals)+7)/8)
+	for i, v := range vals {
+		if v > 0 {
+			packed[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return &BinaryEmbedding{
+		Vector: packed,
+		Dims:   len(vals),
+	}
+}
+
+// Bit returns the value of the i-th dimension.
+func (b BinaryEmbedding) Bit(i int) int {
+	return int(b.Vector[i/8]>>(7-i%8)) & 1
+}
+
+// Unpack returns the embedding as a slice of 0s and 1s.
+func (b BinaryEmbedding) Unpack() []float64 {
+	floats := make([]float64, b.Dims)
+	for i := range floats {
+		floats[i] = float64(b.Bit(i))
+	}
+	return floats
+}
+
+// Hamming returns the Hamming distance between the embeddings.
+func (b BinaryEmbedding) Hamming(o BinaryEmbedding) (int, error) {
+	if b.Dims != o.Dims || len(b.Vector) != len(o.Vector) {
+		return 0, ErrDimMismatch
+	}
+	dist := 0
+	for i := range b.Vector {
+		dist += bits.OnesCount8(b.Vector[i] ^ o.Vector[i])
+	}
+	return dist, nil
+}
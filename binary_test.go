@@ -0,0 +1,37 @@
+package embeddings
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBinaryEmbedding(t *testing.T) {
+	t.Parallel()
+
+	vals := []float64{1, 0, 0, 1, 1, 0, 0, 0, 1, 1}
+	b := NewBinaryEmbedding(vals)
+
+	if b.Dims != len(vals) {
+		t.Fatalf("expected %d dims, got %d", len(vals), b.Dims)
+	}
+	if exp := []byte{0x98, 0xc0}; !reflect.DeepEqual(b.Vector, exp) {
+		t.Fatalf("expected: %v, got: %v", exp, b.Vector)
+	}
+	if got := b.Unpack(); !reflect.DeepEqual(got, vals) {
+		t.Fatalf("expected: %v, got: %v", vals, got)
+	}
+
+	o := NewBinaryEmbedding([]int{1, 1, 0, 1, 1, 0, 0, 0, 1, 0})
+	dist, err := b.Hamming(*o)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dist != 2 {
+		t.Fatalf("expected distance 2, got %d", dist)
+	}
+
+	if _, err := b.Hamming(*NewBinaryEmbedding([]int{1})); !errors.Is(err, ErrDimMismatch) {
+		t.Fatalf("expected error: %v, got: %v", ErrDimMismatch, err)
+	}
+}
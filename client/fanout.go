@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultConcurrency is the default number of fan-out workers.
+	DefaultConcurrency = 4
+)
+
+// FanOut calls fn for every input using at most concurrency workers.
+// It returns the results and errors in the order of inputs.
+// Errors do not stop the remaining inputs from being processed,
+// but inputs not yet dispatched when ctx is done fail with ctx.Err().
+// If concurrency is not positive DefaultConcurrency is used.
+func FanOut[T, R any](ctx context.Context, concurrency int, inputs []T, fn func(context.Context, T) (R, error)) ([]R, []error) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	if concurrency > len(inputs) {
+		concurrency = len(inputs)
+	}
+
+	results := make([]R, len(inputs))
+	errs := make([]error, len(inputs))
+
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				results[i], errs[i] = fn(ctx, inputs[i])
+			}
+		}()
+	}
+
+	for i := range inputs {
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+
+	return results, errs
+}
+
+// JoinErrors joins the non-nil errors returned by FanOut
+// annotating each one with the index of its input.
+// It returns nil if all errors are nil.
+func JoinErrors(errs []error) error {
+	// nolint:prealloc
+	var joined []error
+	for i, err := range errs {
+		if err != nil {
+			joined = append(joined, fmt.Errorf("input %d: %w", i, err))
+		}
+	}
+	return errors.Join(joined...)
+}
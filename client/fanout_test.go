@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanOut(t *testing.T) {
+	t.Parallel()
+
+	t.Run("order", func(t *testing.T) {
+		t.Parallel()
+		inputs := []int{5, 4, 3, 2, 1, 0}
+		var running, peak atomic.Int32
+		results, errs := FanOut(context.Background(), 2, inputs, func(_ context.Context, v int) (int, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Duration(v) * time.Millisecond)
+			return v * 2, nil
+		})
+		assert.Equal(t, []int{10, 8, 6, 4, 2, 0}, results)
+		assert.NoError(t, JoinErrors(errs))
+		assert.LessOrEqual(t, peak.Load(), int32(2))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		errBoom := errors.New("boom")
+		results, errs := FanOut(context.Background(), 0, []int{1, 2, 3}, func(_ context.Context, v int) (int, error) {
+			if v == 2 {
+				return 0, errBoom
+			}
+			return v, nil
+		})
+		assert.Equal(t, []int{1, 0, 3}, results)
+		assert.Equal(t, []error{nil, errBoom, nil}, errs)
+		err := JoinErrors(errs)
+		assert.ErrorIs(t, err, errBoom)
+		assert.EqualError(t, err, "input 1: boom")
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var calls atomic.Int32
+		_, errs := FanOut(ctx, 1, []int{1, 2}, func(_ context.Context, v int) (int, error) {
+			calls.Add(1)
+			return v, nil
+		})
+		assert.Equal(t, int32(0), calls.Load())
+		for _, err := range errs {
+			assert.ErrorIs(t, err, context.Canceled)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		results, errs := FanOut(context.Background(), 2, []int{}, func(_ context.Context, v int) (int, error) {
+			return v, nil
+		})
+		assert.Empty(t, results)
+		assert.Empty(t, errs)
+	})
+}
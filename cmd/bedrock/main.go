@@ -5,27 +5,51 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/milosgajdos/go-embeddings/bedrock"
 )
 
 var (
-	input string
-	model string
+	input     string
+	image     string
+	model     string
+	dims      int
+	inputType string
 )
 
 func init() {
 	flag.StringVar(&input, "input", "what is life", "input data")
 	flag.StringVar(&model, "model", bedrock.TitanTextV1.String(), "model name")
+	flag.StringVar(&image, "image", "", "path to image: only supported by Titan image")
+	flag.IntVar(&dims, "dims", 0, "embedding dimensions: only supported by Titan V2 and Titan image")
+	flag.StringVar(&inputType, "input-type", bedrock.SearchQueryInput.String(), "input type: only supported by Cohere")
 }
 
 func main() {
 	flag.Parse()
 
-	c := bedrock.NewClient(bedrock.WithModelID(model))
+	c, err := bedrock.NewClient(bedrock.WithModelID(model))
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	embReq := &bedrock.Request{
-		InputText: input,
+		InputText:  input,
+		Dimensions: dims,
+	}
+
+	switch bedrock.ModelFamily(model) {
+	case bedrock.TitanImageFamily:
+		if image != "" {
+			data, err := os.ReadFile(image)
+			if err != nil {
+				log.Fatal(err)
+			}
+			embReq.InputImage = data
+		}
+	case bedrock.CohereFamily:
+		embReq.InputType = bedrock.InputType(inputType)
 	}
 
 	embs, err := c.Embed(context.Background(), embReq)
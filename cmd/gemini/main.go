@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/milosgajdos/go-embeddings/gemini"
+)
+
+var (
+	input    string
+	model    string
+	taskType string
+	title    string
+	dims     int
+)
+
+func init() {
+	flag.StringVar(&input, "input", "what is life", "input data")
+	flag.StringVar(&model, "model", gemini.EmbeddingV1.String(), "model name")
+	flag.StringVar(&taskType, "task-type", gemini.RetrQueryTask.String(), "task type")
+	flag.StringVar(&title, "title", "", "title: only relevant for retrival document tasks")
+	flag.IntVar(&dims, "dims", 0, "output dimensionality")
+}
+
+func main() {
+	flag.Parse()
+
+	c := gemini.NewClient()
+
+	embReq := &gemini.EmbeddingRequest{
+		Input:                []string{input},
+		Model:                gemini.Model(model),
+		TaskType:             gemini.TaskType(taskType),
+		Title:                title,
+		OutputDimensionality: dims,
+	}
+
+	embs, err := c.Embed(context.Background(), embReq)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("got %d embeddings", len(embs))
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/milosgajdos/go-embeddings/hf"
+)
+
+var (
+	input        string
+	model        string
+	endpoint     string
+	meanPool     bool
+	waitForModel bool
+)
+
+func init() {
+	flag.StringVar(&input, "input", "what is life", "input data")
+	flag.StringVar(&model, "model", "sentence-transformers/all-MiniLM-L6-v2", "model name")
+	flag.StringVar(&endpoint, "endpoint", "", "inference endpoint URL")
+	flag.BoolVar(&meanPool, "mean-pool", false, "mean-pool token embeddings")
+	flag.BoolVar(&waitForModel, "wait", false, "wait for the model to load")
+}
+
+func main() {
+	flag.Parse()
+
+	c := hf.NewClient(
+		hf.WithEndpointURL(endpoint),
+		hf.WithMeanPool(meanPool),
+	)
+
+	embReq := &hf.EmbeddingRequest{
+		Inputs: []string{input},
+		Model:  model,
+		Options: &hf.RequestOptions{
+			WaitForModel: waitForModel,
+		},
+	}
+
+	embs, err := c.Embed(context.Background(), embReq)
+	if err != nil {
+		var apiErr hf.APIError
+		if errors.Is(err, hf.ErrModelLoading) && errors.As(err, &apiErr) {
+			log.Fatalf("model is loading, retry in %s", apiErr.RetryAfter())
+		}
+		log.Fatal(err)
+	}
+
+	fmt.Printf("got %d embeddings", len(embs))
+}
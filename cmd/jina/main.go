@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/milosgajdos/go-embeddings/document/text"
+	"github.com/milosgajdos/go-embeddings/jina"
+)
+
+var (
+	input     string
+	model     string
+	task      string
+	dims      int
+	chunkSize int
+)
+
+func init() {
+	flag.StringVar(&input, "input", "what is life", "input data")
+	flag.StringVar(&model, "model", jina.EmbeddingsV3.String(), "model name")
+	flag.StringVar(&task, "task", jina.RetrPassageTask.String(), "task: only supported by jina-embeddings-v3")
+	flag.IntVar(&dims, "dims", 0, "embedding dimensions: only supported by jina-embeddings-v3")
+	flag.IntVar(&chunkSize, "chunk-size", 0, "split input into chunks of this size and embed them with late chunking")
+}
+
+func main() {
+	flag.Parse()
+
+	c := jina.NewClient()
+
+	embReq := &jina.EmbeddingRequest{
+		Input:      jina.TextInputs(input),
+		Model:      jina.Model(model),
+		Task:       jina.Task(task),
+		Dimensions: dims,
+	}
+
+	if chunkSize > 0 {
+		s := text.NewRecursiveCharSplitter().
+			WithSplitter(text.NewSplitter().WithChunkSize(chunkSize))
+
+		embs, err := c.EmbedChunks(context.Background(), s, input, embReq)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("got %d chunk embeddings", len(embs))
+		return
+	}
+
+	embs, err := c.Embed(context.Background(), embReq)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("got %d embeddings", len(embs))
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/milosgajdos/go-embeddings/llamacpp"
+)
+
+var (
+	input    string
+	baseURL  string
+	meanPool bool
+)
+
+func init() {
+	flag.StringVar(&input, "input", "what is life", "input data")
+	flag.StringVar(&baseURL, "url", llamacpp.BaseURL, "llama.cpp server URL")
+	flag.BoolVar(&meanPool, "mean-pool", false, "mean-pool token embeddings returned with server pooling disabled")
+}
+
+func main() {
+	flag.Parse()
+
+	ctx := context.Background()
+	c := llamacpp.NewClient(
+		llamacpp.WithBaseURL(baseURL),
+		llamacpp.WithMeanPool(meanPool),
+	)
+
+	props, err := c.Props(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("model: %s, context size: %d\n", props.ModelPath, props.DefaultGenerationSettings.NCtx)
+
+	embs, err := c.Embed(ctx, &llamacpp.EmbeddingRequest{
+		Content: []string{input},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("got %d embeddings", len(embs))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/milosgajdos/go-embeddings/mistral"
+)
+
+var (
+	input string
+	model string
+	dims  int
+	dtype string
+)
+
+func init() {
+	flag.StringVar(&input, "input", "what is life", "input data")
+	flag.StringVar(&model, "model", mistral.EmbedV1.String(), "model name")
+	flag.IntVar(&dims, "dims", 0, "output dimension: only supported by codestral-embed")
+	flag.StringVar(&dtype, "dtype", "", "output data type: only supported by codestral-embed")
+}
+
+func main() {
+	flag.Parse()
+
+	c := mistral.NewClient()
+
+	embReq := &mistral.EmbeddingRequest{
+		Input:           []string{input},
+		Model:           mistral.Model(model),
+		OutputDimension: dims,
+		OutputDType:     mistral.OutputDType(dtype),
+	}
+
+	resp, err := c.Embeddings(context.Background(), embReq)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("got %d embeddings using %d tokens", len(resp.Data), resp.Usage.TotalTokens)
+}
@@ -10,13 +10,19 @@ import (
 )
 
 var (
-	prompt string
-	model  string
+	prompt    string
+	model     string
+	keepAlive string
+	dims      int
+	legacy    bool
 )
 
 func init() {
 	flag.StringVar(&prompt, "prompt", "what is life", "input prompt")
 	flag.StringVar(&model, "model", "", "model name")
+	flag.StringVar(&keepAlive, "keep-alive", "", "how long to keep the model loaded e.g. 5m")
+	flag.IntVar(&dims, "dims", 0, "embedding dimensions")
+	flag.BoolVar(&legacy, "legacy", false, "use legacy embeddings API")
 }
 
 func main() {
@@ -26,11 +32,13 @@ func main() {
 		log.Fatal("missing ollama model")
 	}
 
-	c := ollama.NewClient()
+	c := ollama.NewClient(ollama.WithLegacy(legacy))
 
 	embReq := &ollama.EmbeddingRequest{
-		Prompt: prompt,
-		Model:  model,
+		Input:      prompt,
+		Model:      model,
+		KeepAlive:  keepAlive,
+		Dimensions: dims,
 	}
 
 	embs, err := c.Embed(context.Background(), embReq)
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/milosgajdos/go-embeddings/tei"
+)
+
+var (
+	input   string
+	baseURL string
+	mode    string
+)
+
+func init() {
+	flag.StringVar(&input, "input", "what is life", "input data")
+	flag.StringVar(&baseURL, "url", tei.BaseURL, "TEI server URL")
+	flag.StringVar(&mode, "mode", "dense", "embedding mode: dense, sparse or tokens")
+}
+
+func main() {
+	flag.Parse()
+
+	ctx := context.Background()
+	c := tei.NewClient(tei.WithBaseURL(baseURL))
+
+	info, err := c.Info(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("model: %s, max input length: %d\n", info.ModelID, info.MaxInputLength)
+
+	embReq := &tei.EmbeddingRequest{
+		Inputs:   []string{input},
+		Truncate: true,
+	}
+
+	switch mode {
+	case "sparse":
+		embs, err := c.SparseEmbeddings(ctx, embReq)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("got %d sparse embeddings", len(embs))
+	case "tokens":
+		embs, err := c.TokenEmbeddings(ctx, embReq)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("got %d token embeddings", len(embs[0]))
+	default:
+		embs, err := c.Embed(ctx, embReq)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("got %d embeddings", len(embs))
+	}
+}
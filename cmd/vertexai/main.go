@@ -5,9 +5,9 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/milosgajdos/go-embeddings/vertexai"
-	"golang.org/x/oauth2/google"
 )
 
 var (
@@ -16,6 +16,8 @@ var (
 	truncate bool
 	taskType string
 	title    string
+	dims     int
+	creds    string
 )
 
 func init() {
@@ -24,6 +26,8 @@ func init() {
 	flag.BoolVar(&truncate, "truncate", false, "truncate type")
 	flag.StringVar(&taskType, "task-type", vertexai.RetrQueryTask.String(), "task type")
 	flag.StringVar(&title, "title", "", "title: only relevant for retrival document tasks")
+	flag.StringVar(&creds, "credentials", "", "path to service account key or workload identity config file")
+	flag.IntVar(&dims, "dims", 0, "output dimensionality: only supported by text-embedding-004 and later")
 }
 
 func main() {
@@ -31,14 +35,24 @@ func main() {
 
 	ctx := context.Background()
 
-	ts, err := google.DefaultTokenSource(ctx, vertexai.Scopes)
-	if err != nil {
-		log.Fatalf("token source: %v", err)
+	opts := []vertexai.Option{
+		vertexai.WithModelID(model),
+	}
+
+	switch {
+	case creds != "":
+		opts = append(opts, vertexai.WithCredentialsFile(creds))
+	case os.Getenv("VERTEXAI_TOKEN") == "" &&
+		os.Getenv("VERTEXAI_CREDENTIALS_JSON") == "" &&
+		os.Getenv("GOOGLE_APPLICATION_CREDENTIALS") == "":
+		ts, err := vertexai.NewDefaultTokenSource(ctx, vertexai.Scopes)
+		if err != nil {
+			log.Fatalf("token source: %v", err)
+		}
+		opts = append(opts, vertexai.WithTokenSrc(ts))
 	}
 
-	c := vertexai.NewClient(
-		vertexai.WithTokenSrc(ts),
-		vertexai.WithModelID(model))
+	c := vertexai.NewClient(opts...)
 
 	embReq := &vertexai.EmbeddingRequest{
 		Instances: []vertexai.Instance{
@@ -49,9 +63,14 @@ func main() {
 			},
 		},
 		Params: vertexai.Params{
-			AutoTruncate: truncate,
+			OutputDimensionality: dims,
 		},
 	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "truncate" {
+			embReq.Params.AutoTruncate = &truncate
+		}
+	})
 
 	embs, err := c.Embed(context.Background(), embReq)
 	if err != nil {
@@ -14,6 +14,13 @@ type Embedder[T any] interface {
 	Embed(context.Context, T) ([]*Embedding, error)
 }
 
+// TextSplitter splits text into chunks.
+// It's implemented by document/text splitters.
+type TextSplitter interface {
+	// Split splits text into chunks and returns them.
+	Split(text string) []string
+}
+
 // Embedding is vector embedding.
 type Embedding struct {
 	Vector []float64 `json:"vector"`
@@ -53,3 +60,28 @@ func (s Base64) Decode() (*Embedding, error) {
 		Vector: floats,
 	}, nil
 }
+
+// DecodeFloat32 decodes base64 encoded string of little-endian
+// float32 values into a slice of floats. This is the encoding
+// of the base64 embeddings returned by OpenAI, Voyage and Jina APIs.
+func (s Base64) DecodeFloat32() (*Embedding, error) {
+	decoded, err := base64.StdEncoding.DecodeString(string(s))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(decoded)%4 != 0 {
+		return nil, fmt.Errorf("invalid base64 encoded string length")
+	}
+
+	floats := make([]float64, len(decoded)/4)
+
+	for i := 0; i < len(floats); i++ {
+		bits := binary.LittleEndian.Uint32(decoded[i*4 : (i+1)*4])
+		floats[i] = float64(math.Float32frombits(bits))
+	}
+
+	return &Embedding{
+		Vector: floats,
+	}, nil
+}
@@ -67,3 +67,51 @@ func TestBase64Decode(t *testing.T) {
 		})
 	}
 }
+
+func TestBase64DecodeFloat32(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name    string
+		given   string
+		exp     []float64
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			given:   "AACAPwAAAEAAAIDA",
+			exp:     []float64{1.0, 2.0, -4.0},
+			wantErr: false,
+		},
+		{
+			name:    "invalid length",
+			given:   "AACAPwA=",
+			wantErr: true,
+		},
+		{
+			name:    "invalid",
+			given:   "garbage",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			embBase64 := Base64(tc.given)
+			got, err := embBase64.DecodeFloat32()
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected error")
+			}
+			if !reflect.DeepEqual(got.Vector, tc.exp) {
+				t.Fatalf("expected: %v, got: %v", tc.exp, got.Vector)
+			}
+		})
+	}
+}
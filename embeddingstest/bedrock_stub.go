@@ -0,0 +1,312 @@
+package embeddingstest
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/milosgajdos/go-embeddings/bedrock"
+)
+
+// titanTextRequest is the Titan text embeddings request schema.
+type titanTextRequest struct {
+	InputText      string   `json:"inputText"`
+	Dimensions     *int     `json:"dimensions"`
+	Normalize      *bool    `json:"normalize"`
+	EmbeddingTypes []string `json:"embeddingTypes"`
+}
+
+type titanEmbeddingConfig struct {
+	OutputEmbeddingLength int `json:"outputEmbeddingLength"`
+}
+
+// titanImageRequest is the Titan multimodal embeddings request schema.
+type titanImageRequest struct {
+	InputText       string                `json:"inputText"`
+	InputImage      string                `json:"inputImage"`
+	EmbeddingConfig *titanEmbeddingConfig `json:"embeddingConfig"`
+}
+
+type titanEmbeddingsByType struct {
+	Float  []float64 `json:"float,omitempty"`
+	Binary []int     `json:"binary,omitempty"`
+}
+
+type titanResponse struct {
+	Embedding           []float64              `json:"embedding"`
+	InputTextTokenCount int                    `json:"inputTextTokenCount"`
+	EmbeddingsByType    *titanEmbeddingsByType `json:"embeddingsByType,omitempty"`
+}
+
+// BedrockStub is the AWS Bedrock InvokeModel API emulator.
+// It implements bedrock.InvokeModelAPI.
+//
+// It validates the model invocations against the request schema of
+// the model family and returns deterministic embeddings, see Vector.
+// The injected fault status is returned as the matching Bedrock API
+// error e.g. 429 as ThrottlingException. RateLimit makes the API
+// return ThrottlingException and MaxInputLen is the model context
+// length in runes: the longer Titan inputs are rejected while Cohere
+// inputs are truncated as requested by the truncate parameter.
+type BedrockStub struct {
+	opts  Options
+	inj   *injector
+	mu    sync.Mutex
+	calls []*bedrockruntime.InvokeModelInput
+}
+
+// NewBedrockStub creates a new Bedrock InvokeModel API emulator and returns it.
+func NewBedrockStub(opts ...Option) *BedrockStub {
+	options := newOptions(opts...)
+
+	return &BedrockStub{
+		opts: options,
+		inj:  newInjector(options),
+	}
+}
+
+// Inject queues the faults injected into the following model invocations, one fault per invocation.
+// The invocations made after the injected faults are exhausted use the emulator options.
+func (b *BedrockStub) Inject(faults ...Fault) *BedrockStub {
+	b.inj.inject(faults...)
+	return b
+}
+
+// Calls returns the model invocations received by the emulator.
+func (b *BedrockStub) Calls() []*bedrockruntime.InvokeModelInput {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	calls := make([]*bedrockruntime.InvokeModelInput, len(b.calls))
+	copy(calls, b.calls)
+	return calls
+}
+
+// Reset clears the received model invocations, the injected faults and the rate limiter.
+func (b *BedrockStub) Reset() {
+	b.mu.Lock()
+	b.calls = nil
+	b.mu.Unlock()
+	b.inj.reset()
+}
+
+// InvokeModel invokes the embeddings model.
+func (b *BedrockStub) InvokeModel(ctx context.Context, params *bedrockruntime.InvokeModelInput, _ ...func(*bedrockruntime.Options)) (*bedrockruntime.InvokeModelOutput, error) {
+	b.mu.Lock()
+	b.calls = append(b.calls, params)
+	b.mu.Unlock()
+
+	fault, _, limited := b.inj.next()
+
+	if err := sleep(ctx, fault.Delay); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case limited:
+		return nil, &types.ThrottlingException{Message: aws.String("Too many requests, please wait before trying again.")}
+	case fault.Status != 0:
+		return nil, bedrockError(fault.Status, http.StatusText(fault.Status))
+	case fault.Malformed:
+		return &bedrockruntime.InvokeModelOutput{
+			Body:        []byte(malformedJSON),
+			ContentType: aws.String("application/json"),
+		}, nil
+	}
+
+	body, err := b.invoke(params)
+	if err != nil {
+		var apiErr *apiError
+		if errors.As(err, &apiErr) {
+			return nil, bedrockError(apiErr.status, apiErr.msg)
+		}
+		return nil, err
+	}
+
+	return &bedrockruntime.InvokeModelOutput{
+		Body:        body,
+		ContentType: aws.String("application/json"),
+	}, nil
+}
+
+// invoke validates the model invocation and returns the response body.
+func (b *BedrockStub) invoke(params *bedrockruntime.InvokeModelInput) ([]byte, error) {
+	modelID := aws.ToString(params.ModelId)
+	if modelID == "" {
+		return nil, badRequest("model identifier is required")
+	}
+	if ct := aws.ToString(params.ContentType); ct != "" && ct != "application/json" {
+		return nil, badRequest("unsupported content type: %s", ct)
+	}
+
+	var (
+		resp any
+		err  error
+	)
+	switch bedrock.ModelFamily(modelID) {
+	case bedrock.TitanImageFamily:
+		resp, err = b.titanImage(params.Body)
+	case bedrock.CohereFamily:
+		resp, err = b.cohere(params.Body)
+	default:
+		resp, err = b.titanText(modelID, params.Body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(resp)
+}
+
+// titanText handles the Titan text embeddings invocation.
+func (b *BedrockStub) titanText(modelID string, body []byte) (any, error) {
+	req := new(titanTextRequest)
+	if err := decodeBedrockJSON(body, req); err != nil {
+		return nil, err
+	}
+	if req.InputText == "" {
+		return nil, badRequest("inputText is required")
+	}
+
+	dims := b.opts.Dims
+	if strings.Contains(modelID, "titan-embed-text-v2") {
+		if req.Dimensions != nil {
+			if !slices.Contains([]int{256, 512, 1024}, *req.Dimensions) {
+				return nil, badRequest("dimensions must be one of 256, 512 or 1024")
+			}
+			dims = *req.Dimensions
+		}
+	} else if req.Dimensions != nil || req.Normalize != nil || req.EmbeddingTypes != nil {
+		return nil, badRequest("extraneous key [dimensions, normalize, embeddingTypes] is not permitted")
+	}
+
+	if _, truncated := truncate(req.InputText, b.opts.MaxInputLen); truncated {
+		return nil, badRequest("Too many input tokens. Max input tokens: %d", b.opts.MaxInputLen)
+	}
+
+	normalize := b.opts.Normalize
+	if req.Normalize != nil {
+		normalize = *req.Normalize
+	}
+	vec := Vector(req.InputText, dims, b.opts.Seed, normalize)
+
+	resp := &titanResponse{
+		Embedding:           vec,
+		InputTextTokenCount: countTokens(req.InputText),
+	}
+	if len(req.EmbeddingTypes) == 0 {
+		return resp, nil
+	}
+
+	resp.EmbeddingsByType = new(titanEmbeddingsByType)
+	for _, t := range req.EmbeddingTypes {
+		switch t {
+		case "float":
+			resp.EmbeddingsByType.Float = vec
+		case "binary":
+			resp.EmbeddingsByType.Binary = make([]int, len(vec))
+			for i, f := range vec {
+				if f > 0 {
+					resp.EmbeddingsByType.Binary[i] = 1
+				}
+			}
+		default:
+			return nil, badRequest("invalid embeddingTypes: %s", t)
+		}
+	}
+	return resp, nil
+}
+
+// titanImage handles the Titan multimodal embeddings invocation.
+// The image is embedded by its base64 encoding.
+func (b *BedrockStub) titanImage(body []byte) (any, error) {
+	req := new(titanImageRequest)
+	if err := decodeBedrockJSON(body, req); err != nil {
+		return nil, err
+	}
+	if req.InputText == "" && req.InputImage == "" {
+		return nil, badRequest("at least one of inputText and inputImage is required")
+	}
+	if req.InputImage != "" {
+		if _, err := base64.StdEncoding.DecodeString(req.InputImage); err != nil {
+			return nil, badRequest("inputImage is not valid base64: %v", err)
+		}
+	}
+
+	dims := b.opts.Dims
+	if req.EmbeddingConfig != nil {
+		dims = req.EmbeddingConfig.OutputEmbeddingLength
+		if !slices.Contains([]int{256, 384, 1024}, dims) {
+			return nil, badRequest("outputEmbeddingLength must be one of 256, 384 or 1024")
+		}
+	}
+
+	if _, truncated := truncate(req.InputText, b.opts.MaxInputLen); truncated {
+		return nil, badRequest("Too many input tokens. Max input tokens: %d", b.opts.MaxInputLen)
+	}
+
+	return &titanResponse{
+		Embedding:           Vector(req.InputText+req.InputImage, dims, b.opts.Seed, b.opts.Normalize),
+		InputTextTokenCount: countTokens(req.InputText),
+	}, nil
+}
+
+// cohere handles the Cohere embeddings invocation.
+// NOTE: Bedrock does not truncate Cohere inputs by default.
+func (b *BedrockStub) cohere(body []byte) (any, error) {
+	req := new(cohereRequest)
+	if err := decodeBedrockJSON(body, req); err != nil {
+		return nil, err
+	}
+	if req.Model != "" {
+		return nil, badRequest("extraneous key [model] is not permitted")
+	}
+	if req.InputType == "" {
+		return nil, badRequest("required key [input_type] not found")
+	}
+	resp, err := cohereEmbed(b.opts, req, "NONE")
+	if err != nil {
+		return nil, err
+	}
+	resp.ID = fmt.Sprintf("%016x", len(b.Calls()))
+	return resp, nil
+}
+
+// decodeBedrockJSON decodes the model invocation body into v.
+// Unknown keys are rejected like by the Bedrock API.
+func decodeBedrockJSON(body []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return badRequest("Malformed input request: %v, please reformat your input and try again.", err)
+	}
+	return nil
+}
+
+// bedrockError returns the Bedrock API error matching the HTTP status.
+func bedrockError(status int, msg string) error {
+	switch status {
+	case http.StatusBadRequest:
+		return &types.ValidationException{Message: aws.String(msg)}
+	case http.StatusForbidden:
+		return &types.AccessDeniedException{Message: aws.String(msg)}
+	case http.StatusNotFound:
+		return &types.ResourceNotFoundException{Message: aws.String(msg)}
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return &types.ModelTimeoutException{Message: aws.String(msg)}
+	case http.StatusFailedDependency:
+		return &types.ModelErrorException{Message: aws.String(msg)}
+	case http.StatusTooManyRequests:
+		return &types.ThrottlingException{Message: aws.String(msg)}
+	}
+	return &types.InternalServerException{Message: aws.String(msg)}
+}
@@ -0,0 +1,119 @@
+package embeddingstest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/milosgajdos/go-embeddings/bedrock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBedrockClient(t *testing.T, stub *BedrockStub, model bedrock.Model) *bedrock.Client {
+	t.Helper()
+	c, err := bedrock.NewClient(
+		bedrock.WithModelID(model.String()),
+		bedrock.WithBedrockClient(stub),
+	)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestBedrockStub(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	stub := NewBedrockStub()
+
+	c := newBedrockClient(t, stub, bedrock.TitanTextV2)
+	resp, err := c.Embeddings(ctx, &bedrock.Request{
+		InputText:      "foo bar",
+		Dimensions:     256,
+		EmbeddingTypes: []bedrock.EmbeddingType{bedrock.FloatEmbedding, bedrock.BinaryEmbedding},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Embedding, 256)
+	assert.Equal(t, 2, resp.InputTextTokenCount)
+	bin, err := resp.ToBinaryEmbeddings()
+	assert.NoError(t, err)
+	assert.Equal(t, Vector("foo bar", 256, 0, true), resp.Embedding)
+	assert.Equal(t, 256, bin[0].Dims)
+
+	c = newBedrockClient(t, stub, bedrock.TitanImageV1)
+	embs, err := c.Embed(ctx, &bedrock.Request{InputImage: []byte("image")})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 1)
+
+	c = newBedrockClient(t, stub, bedrock.CohereEnglishV3)
+	embs, err = c.Embed(ctx, &bedrock.Request{
+		Texts:     []string{"foo", "bar"},
+		InputType: bedrock.SearchQueryInput,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 2)
+	assert.Equal(t, Vector("foo", DefaultDims, 0, true), embs[0].Vector)
+
+	assert.Len(t, stub.Calls(), 3)
+	stub.Reset()
+	assert.Empty(t, stub.Calls())
+}
+
+func TestBedrockStubErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	stub := NewBedrockStub(WithMaxInputLen(3, false))
+	c := newBedrockClient(t, stub, bedrock.TitanTextV2)
+	req := &bedrock.Request{InputText: "foo"}
+
+	stub.Inject(
+		Fault{Status: http.StatusTooManyRequests},
+		Fault{Status: http.StatusInternalServerError},
+		Fault{Malformed: true},
+	)
+
+	_, err := c.Embed(ctx, req)
+	assert.ErrorIs(t, err, bedrock.ErrThrottled)
+
+	_, err = c.Embed(ctx, req)
+	var internalErr *types.InternalServerException
+	assert.ErrorAs(t, err, &internalErr)
+
+	_, err = c.Embed(ctx, req)
+	assert.Error(t, err)
+
+	_, err = c.Embed(ctx, &bedrock.Request{InputText: "foobar"})
+	assert.ErrorIs(t, err, bedrock.ErrValidation)
+
+	_, err = stub.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		ModelId: aws.String(bedrock.TitanTextV1.String()),
+		Body:    []byte(`{"inputText":"foo","dimensions":256}`),
+	})
+	var validationErr *types.ValidationException
+	assert.ErrorAs(t, err, &validationErr)
+
+	stub.Inject(Fault{Delay: time.Minute})
+	tctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = c.Embed(tctx, req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBedrockStubRateLimit(t *testing.T) {
+	t.Parallel()
+
+	stub := NewBedrockStub(WithRateLimit(1, time.Minute))
+	c := newBedrockClient(t, stub, bedrock.TitanTextV2)
+
+	ctx := context.Background()
+	_, err := c.Embed(ctx, &bedrock.Request{InputText: "foo"})
+	assert.NoError(t, err)
+	_, err = c.Embed(ctx, &bedrock.Request{InputText: "foo"})
+	assert.ErrorIs(t, err, bedrock.ErrThrottled)
+
+	var _ bedrock.InvokeModelAPI = stub
+}
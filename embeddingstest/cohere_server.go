@@ -0,0 +1,146 @@
+package embeddingstest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	// CohereMaxTexts is the maximum number
+	// of texts in a single Cohere API request.
+	CohereMaxTexts = 96
+)
+
+var (
+	cohereInputTypes = []string{"search_document", "search_query", "classification", "clustering"}
+	cohereTruncates  = []string{"", "NONE", "START", "END"}
+)
+
+// cohereRequest is the Cohere embed API request schema.
+// It's shared by the Cohere models hosted on AWS Bedrock.
+type cohereRequest struct {
+	Texts          []string `json:"texts"`
+	Model          string   `json:"model,omitempty"`
+	InputType      string   `json:"input_type"`
+	Truncate       string   `json:"truncate"`
+	EmbeddingTypes []string `json:"embedding_types"`
+}
+
+type cohereBilledUnits struct {
+	InputTokens int `json:"input_tokens"`
+}
+
+type cohereAPIVersion struct {
+	Version string `json:"version"`
+}
+
+type cohereMeta struct {
+	APIVersion  cohereAPIVersion  `json:"api_version"`
+	BilledUnits cohereBilledUnits `json:"billed_units"`
+}
+
+type cohereResponse struct {
+	ID           string      `json:"id"`
+	Texts        []string    `json:"texts"`
+	Embeddings   any         `json:"embeddings"`
+	Meta         *cohereMeta `json:"meta,omitempty"`
+	ResponseType string      `json:"response_type"`
+}
+
+// NewCohereServer starts the Cohere embed API emulator and returns it.
+// Use its URL as the cohere client base URL. It returns float embeddings
+// unless embedding_types are requested. Inputs longer than MaxInputLen
+// are truncated as requested by the truncate parameter.
+func NewCohereServer(opts ...Option) *Server {
+	s := newServer(bearerAuth, cohereError, opts...)
+	s.handle("POST /v1/embed", func(_ *http.Request, body []byte) (any, error) {
+		req := new(cohereRequest)
+		if err := decodeJSON(body, req); err != nil {
+			return nil, err
+		}
+		// NOTE: input_type is only required by v3 and later models.
+		if req.InputType == "" && !strings.HasSuffix(req.Model, "-v2.0") {
+			return nil, badRequest("input_type is required for %s", req.Model)
+		}
+		resp, err := cohereEmbed(s.opts, req, "END")
+		if err != nil {
+			return nil, err
+		}
+		resp.ID = fmt.Sprintf("%016x", len(s.Requests()))
+		resp.Meta = &cohereMeta{
+			APIVersion: cohereAPIVersion{Version: "1"},
+		}
+		for _, text := range resp.Texts {
+			resp.Meta.BilledUnits.InputTokens += countTokens(text)
+		}
+		return resp, nil
+	})
+	return s.start()
+}
+
+// cohereEmbed validates the request and embeds its texts.
+// The texts are truncated using defaultTrunc unless truncate is set.
+func cohereEmbed(opts Options, req *cohereRequest, defaultTrunc string) (*cohereResponse, error) {
+	if len(req.Texts) == 0 || len(req.Texts) > CohereMaxTexts {
+		return nil, badRequest("texts must contain between 1 and %d texts", CohereMaxTexts)
+	}
+	if req.InputType != "" && !slices.Contains(cohereInputTypes, req.InputType) {
+		return nil, badRequest("invalid input_type: %s", req.InputType)
+	}
+	if !slices.Contains(cohereTruncates, req.Truncate) {
+		return nil, badRequest("invalid truncate: %s", req.Truncate)
+	}
+	trunc := req.Truncate
+	if trunc == "" {
+		trunc = defaultTrunc
+	}
+
+	vecs := make([][]float64, 0, len(req.Texts))
+	for i, text := range req.Texts {
+		truncated, ok := truncate(text, opts.MaxInputLen)
+		switch {
+		case !ok:
+		case trunc == "NONE":
+			return nil, badRequest("text %d is too long, the maximum length is %d", i, opts.MaxInputLen)
+		case trunc == "START":
+			runes := []rune(text)
+			truncated = string(runes[len(runes)-opts.MaxInputLen:])
+		}
+		vecs = append(vecs, Vector(truncated, opts.Dims, opts.Seed, opts.Normalize))
+	}
+
+	resp := &cohereResponse{
+		Texts:        req.Texts,
+		Embeddings:   vecs,
+		ResponseType: "embeddings_floats",
+	}
+	if len(req.EmbeddingTypes) == 0 {
+		return resp, nil
+	}
+
+	byType := make(map[string]any, len(req.EmbeddingTypes))
+	for _, dtype := range req.EmbeddingTypes {
+		embs := make([]any, 0, len(vecs))
+		for _, vec := range vecs {
+			emb, err := encodeVector(vec, dtype, false)
+			if err != nil {
+				return nil, err
+			}
+			embs = append(embs, emb)
+		}
+		byType[dtype] = embs
+	}
+	resp.Embeddings = byType
+	resp.ResponseType = "embeddings_by_type"
+
+	return resp, nil
+}
+
+// cohereError returns the Cohere API error.
+func cohereError(_ int, msg string) any {
+	return map[string]any{
+		"message": msg,
+	}
+}
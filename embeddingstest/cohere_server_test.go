@@ -0,0 +1,72 @@
+package embeddingstest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings/cohere"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCohereServer(t *testing.T) {
+	t.Parallel()
+
+	s := NewCohereServer(WithAPIKey("key"), WithMaxInputLen(3, false))
+	defer s.Close()
+
+	ctx := context.Background()
+	c := cohere.NewClient(cohere.WithBaseURL(s.URL), cohere.WithAPIKey("key"))
+
+	embs, err := c.Embed(ctx, &cohere.EmbeddingRequest{
+		Texts:     []string{"foo", "foobar"},
+		Model:     cohere.EnglishV3,
+		InputType: cohere.SearchDocInput,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 2)
+	assert.Equal(t, embs[0].Vector, embs[1].Vector)
+
+	embs, err = c.Embed(ctx, &cohere.EmbeddingRequest{
+		Texts:     []string{"barfoo"},
+		Model:     cohere.EnglishV3,
+		InputType: cohere.SearchDocInput,
+		Truncate:  cohere.StartTrunc,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Vector("foo", DefaultDims, 0, true), embs[0].Vector)
+
+	testCases := []struct {
+		name string
+		req  *cohere.EmbeddingRequest
+	}{
+		{"missing input type", &cohere.EmbeddingRequest{Texts: []string{"foo"}, Model: cohere.EnglishV3}},
+		{"invalid input type", &cohere.EmbeddingRequest{Texts: []string{"foo"}, InputType: "foo"}},
+		{"no truncation", &cohere.EmbeddingRequest{Texts: []string{"foobar"}, InputType: cohere.SearchDocInput, Truncate: cohere.NoneTrunc}},
+		{"no texts", &cohere.EmbeddingRequest{InputType: cohere.SearchDocInput}},
+	}
+
+	for _, tc := range testCases {
+		_, err := c.Embed(ctx, tc.req)
+		var apiErr cohere.APIError
+		assert.ErrorAs(t, err, &apiErr, tc.name)
+		assert.NotEmpty(t, apiErr.Message, tc.name)
+	}
+
+	_, err = c.Embed(ctx, &cohere.EmbeddingRequest{Texts: []string{"foo"}, Model: cohere.EnglishV2})
+	assert.NoError(t, err)
+
+	header := http.Header{"Authorization": {"Bearer key"}}
+	resp, data := post(t, s.URL+"/v1/embed", header,
+		`{"texts":["foo"],"input_type":"search_query","embedding_types":["float","int8","ubinary"]}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "embeddings_by_type", data["response_type"])
+	byType := data["embeddings"].(map[string]any)
+	for _, dtype := range []string{"float", "int8", "ubinary"} {
+		assert.Len(t, byType[dtype], 1, dtype)
+	}
+
+	resp, _ = post(t, s.URL+"/v1/embed", header, `{"texts":["`+strings.Repeat("a", 97)+`"],"input_type":"foo"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
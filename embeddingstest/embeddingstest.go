@@ -0,0 +1,394 @@
+// Package embeddingstest provides fake embedders for testing code
+// which depends on embeddings.Embedder or embeddings.SparseEmbedder
+// without accessing the network.
+//
+// The fake embedders are deterministic: the same input text always
+// embeds into the same vector, which is seeded by the input text hash.
+// Their behaviour can be scripted to return errors, add latency,
+// enforce rate limits or truncate the inputs and the responses.
+//
+// The package also provides httptest based emulators of the provider
+// HTTP APIs and a stub of the AWS Bedrock InvokeModel API, which let
+// the API clients be tested end to end. The emulators validate the
+// API requests and can inject API errors, malformed responses
+// and slow responses, see Fault.
+package embeddingstest
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/milosgajdos/go-embeddings"
+)
+
+const (
+	// DefaultDims is the default number of embedding dimensions.
+	DefaultDims = 8
+)
+
+// Options are fake embedder and API emulator options.
+type Options struct {
+	// Dims is the number of embedding dimensions.
+	Dims int
+	// Normalize the embeddings to unit length.
+	Normalize bool
+	// Seed is mixed into the input text hash.
+	// Embedders with different seeds emulate different models.
+	Seed uint64
+	// Err is returned by every call which has no scripted Step.
+	// NOTE: Err is ignored by the API emulators, see Fault.
+	Err error
+	// Latency delays every call.
+	Latency time.Duration
+	// RateLimit is the maximum number of calls allowed in RateWindow.
+	// The rate limit is disabled if RateLimit is 0.
+	RateLimit  int
+	RateWindow time.Duration
+	// MaxInputLen is the maximum input length in runes.
+	// The input length is not limited if MaxInputLen is 0.
+	MaxInputLen int
+	// Truncate the inputs exceeding MaxInputLen
+	// instead of returning ErrInputTooLong.
+	Truncate bool
+	// APIKey authenticates the requests sent to the API emulators.
+	// The requests are not authenticated if APIKey is empty.
+	APIKey string
+	// Now returns the current time.
+	Now func() time.Time
+}
+
+// Option is functional option.
+type Option func(*Options)
+
+// Step scripts the behaviour of a single embedder call.
+type Step struct {
+	// Err is returned instead of the embeddings.
+	Err error
+	// Latency delays the call.
+	Latency time.Duration
+	// Limit truncates the response to the first Limit embeddings.
+	// The response is not truncated if Limit is 0.
+	Limit int
+}
+
+// Embedder is a deterministic fake embedder.
+// It's safe for concurrent use.
+type Embedder[T any] struct {
+	opts   Options
+	inputs func(T) ([]string, error)
+	mu     sync.Mutex
+	steps  []Step
+	calls  []T
+	rl     rateLimiter
+}
+
+// New creates a new fake embedder and returns it.
+// The inputs func extracts the texts to embed from the requests.
+// By default it returns unit length embeddings with DefaultDims dimensions.
+func New[T any](inputs func(T) ([]string, error), opts ...Option) *Embedder[T] {
+	options := newOptions(opts...)
+
+	return &Embedder[T]{
+		opts:   options,
+		inputs: inputs,
+		rl:     newRateLimiter(options),
+	}
+}
+
+// newOptions returns the default options with opts applied.
+func newOptions(opts ...Option) Options {
+	options := Options{
+		Dims:       DefaultDims,
+		Normalize:  true,
+		RateWindow: time.Second,
+		Truncate:   true,
+		Now:        time.Now,
+	}
+
+	for _, apply := range opts {
+		apply(&options)
+	}
+
+	if options.Dims <= 0 {
+		options.Dims = DefaultDims
+	}
+
+	return options
+}
+
+// WithDims sets the number of embedding dimensions.
+// DefaultDims is used if dims is not positive.
+func WithDims(dims int) Option {
+	return func(o *Options) {
+		o.Dims = dims
+	}
+}
+
+// WithNormalize sets the embeddings normalization.
+func WithNormalize(normalize bool) Option {
+	return func(o *Options) {
+		o.Normalize = normalize
+	}
+}
+
+// WithSeed sets the embeddings seed.
+func WithSeed(seed uint64) Option {
+	return func(o *Options) {
+		o.Seed = seed
+	}
+}
+
+// WithError makes every unscripted call fail with err.
+func WithError(err error) Option {
+	return func(o *Options) {
+		o.Err = err
+	}
+}
+
+// WithLatency delays every call by d.
+func WithLatency(d time.Duration) Option {
+	return func(o *Options) {
+		o.Latency = d
+	}
+}
+
+// WithRateLimit allows at most limit calls in the given window.
+// The calls exceeding the limit fail with RateLimitError.
+func WithRateLimit(limit int, window time.Duration) Option {
+	return func(o *Options) {
+		o.RateLimit = limit
+		o.RateWindow = window
+	}
+}
+
+// WithMaxInputLen sets the maximum input length in runes.
+// The longer inputs are truncated if truncate is true,
+// otherwise the call fails with ErrInputTooLong.
+func WithMaxInputLen(n int, truncate bool) Option {
+	return func(o *Options) {
+		o.MaxInputLen = n
+		o.Truncate = truncate
+	}
+}
+
+// WithAPIKey sets the API key required by the API emulators.
+func WithAPIKey(apiKey string) Option {
+	return func(o *Options) {
+		o.APIKey = apiKey
+	}
+}
+
+// WithClock sets the clock used by the rate limiter.
+func WithClock(now func() time.Time) Option {
+	return func(o *Options) {
+		o.Now = now
+	}
+}
+
+// Script queues the steps which script the following calls, one step per call.
+// The calls made after the scripted steps are exhausted use the embedder options.
+func (e *Embedder[T]) Script(steps ...Step) *Embedder[T] {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.steps = append(e.steps, steps...)
+	return e
+}
+
+// Calls returns the requests the embedder was called with.
+func (e *Embedder[T]) Calls() []T {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	calls := make([]T, len(e.calls))
+	copy(calls, e.calls)
+	return calls
+}
+
+// Reset clears the recorded calls, the scripted steps and the rate limiter.
+func (e *Embedder[T]) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.steps, e.calls = nil, nil
+	e.rl.reset()
+}
+
+// Embed returns deterministic embeddings for every input in the request.
+func (e *Embedder[T]) Embed(ctx context.Context, req T) ([]*embeddings.Embedding, error) {
+	inputs, err := e.texts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	embs := make([]*embeddings.Embedding, 0, len(inputs))
+	for _, input := range inputs {
+		embs = append(embs, &embeddings.Embedding{
+			Vector: Vector(input, e.opts.Dims, e.opts.Seed, e.opts.Normalize),
+		})
+	}
+
+	return embs, nil
+}
+
+// texts runs the next step of the call and returns the texts to embed.
+// The texts are truncated and limited according to the step and the options.
+func (e *Embedder[T]) texts(ctx context.Context, req T) ([]string, error) {
+	step, err := e.next(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sleep(ctx, step.Latency); err != nil {
+		return nil, err
+	}
+
+	if step.Err != nil {
+		return nil, step.Err
+	}
+
+	inputs, err := e.inputs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		input, err := e.truncate(input)
+		if err != nil {
+			return nil, err
+		}
+		texts = append(texts, input)
+	}
+
+	if step.Limit > 0 && step.Limit < len(texts) {
+		texts = texts[:step.Limit]
+	}
+
+	return texts, nil
+}
+
+// next records the call and returns its step.
+// It returns RateLimitError if the rate limit is exceeded.
+func (e *Embedder[T]) next(req T) (Step, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.calls = append(e.calls, req)
+
+	if retryAfter, ok := e.rl.allow(); !ok {
+		return Step{}, &RateLimitError{
+			RetryAfter: retryAfter,
+		}
+	}
+
+	if len(e.steps) > 0 {
+		step := e.steps[0]
+		e.steps = e.steps[1:]
+		return step, nil
+	}
+
+	return Step{
+		Err:     e.opts.Err,
+		Latency: e.opts.Latency,
+	}, nil
+}
+
+// truncate enforces the maximum input length.
+func (e *Embedder[T]) truncate(input string) (string, error) {
+	input, truncated := truncate(input, e.opts.MaxInputLen)
+	if truncated && !e.opts.Truncate {
+		return "", ErrInputTooLong
+	}
+	return input, nil
+}
+
+// truncate truncates the input to at most n runes.
+// It reports whether the input was truncated.
+// The input is not truncated if n is not positive.
+func truncate(input string, n int) (string, bool) {
+	if n <= 0 {
+		return input, false
+	}
+	runes := []rune(input)
+	if len(runes) <= n {
+		return input, false
+	}
+	return string(runes[:n]), true
+}
+
+// rateLimiter allows at most limit calls in a sliding time window.
+// It's not safe for concurrent use.
+type rateLimiter struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+	times  []time.Time
+}
+
+// newRateLimiter creates a rate limiter configured by the options.
+func newRateLimiter(opts Options) rateLimiter {
+	return rateLimiter{
+		limit:  opts.RateLimit,
+		window: opts.RateWindow,
+		now:    opts.Now,
+	}
+}
+
+// allow records the call if it's allowed. Otherwise
+// it returns the time until the next call is allowed.
+func (r *rateLimiter) allow() (time.Duration, bool) {
+	if r.limit <= 0 {
+		return 0, true
+	}
+	now := r.now()
+	times := r.times[:0]
+	for _, t := range r.times {
+		if now.Sub(t) < r.window {
+			times = append(times, t)
+		}
+	}
+	r.times = times
+	if len(r.times) >= r.limit {
+		return r.window - now.Sub(r.times[0]), false
+	}
+	r.times = append(r.times, now)
+	return 0, true
+}
+
+// reset forgets the recorded calls.
+func (r *rateLimiter) reset() {
+	r.times = nil
+}
+
+// Vector returns a deterministic vector with the given number of dimensions
+// which is seeded by the hash of the text mixed with seed.
+// The vector is normalized to unit length if normalize is true.
+// It returns an empty vector if dims is not positive.
+func Vector(text string, dims int, seed uint64, normalize bool) []float64 {
+	if dims <= 0 {
+		return []float64{}
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(text))
+	// nolint:gosec
+	r := rand.New(rand.NewPCG(h.Sum64(), seed))
+
+	vec := make([]float64, dims)
+	var norm float64
+	for i := range vec {
+		vec[i] = r.NormFloat64()
+		norm += vec[i] * vec[i]
+	}
+
+	if normalize && norm > 0 {
+		norm = math.Sqrt(norm)
+		for i := range vec {
+			vec[i] /= norm
+		}
+	}
+
+	return vec
+}
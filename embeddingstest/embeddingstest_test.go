@@ -0,0 +1,140 @@
+package embeddingstest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/milosgajdos/go-embeddings/openai"
+	"github.com/stretchr/testify/assert"
+)
+
+func norm(vec []float64) float64 {
+	var n float64
+	for _, f := range vec {
+		n += f * f
+	}
+	return math.Sqrt(n)
+}
+
+func TestVector(t *testing.T) {
+	t.Parallel()
+
+	v := Vector("foo", 16, 0, true)
+	assert.Len(t, v, 16)
+	assert.InDelta(t, 1.0, norm(v), 1e-12)
+	assert.Equal(t, v, Vector("foo", 16, 0, true))
+	assert.NotEqual(t, v, Vector("bar", 16, 0, true))
+	assert.NotEqual(t, v, Vector("foo", 16, 1, true))
+	assert.Greater(t, math.Abs(norm(Vector("foo", 16, 0, false))-1), 1e-9)
+	assert.Empty(t, Vector("foo", -1, 0, true))
+}
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e := NewOpenAI(WithDims(32))
+	embs, err := e.Embed(ctx, &openai.EmbeddingRequest{Input: []string{"foo", "bar", "foo"}})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 3)
+	assert.Len(t, embs[0].Vector, 32)
+	assert.Equal(t, embs[0], embs[2])
+	assert.NotEqual(t, embs[0], embs[1])
+
+	_, err = e.Embed(ctx, &openai.EmbeddingRequest{Input: 42})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	assert.Len(t, e.Calls(), 2)
+
+	e.Reset()
+	assert.Empty(t, e.Calls())
+
+	var _ embeddings.Embedder[*openai.EmbeddingRequest] = e
+
+	for _, dims := range []int{0, -1} {
+		embs, err := NewOpenAI(WithDims(dims)).Embed(ctx, &openai.EmbeddingRequest{Input: "foo"})
+		assert.NoError(t, err)
+		assert.Len(t, embs[0].Vector, DefaultDims)
+	}
+}
+
+func TestScript(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	errFoo := errors.New("foo")
+	req := []string{"foo", "bar", "baz"}
+
+	e := New(func(r []string) ([]string, error) { return r, nil }).
+		Script(
+			Step{Err: errFoo},
+			Step{Limit: 2},
+		)
+
+	_, err := e.Embed(ctx, req)
+	assert.ErrorIs(t, err, errFoo)
+
+	embs, err := e.Embed(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, embs, 2)
+
+	embs, err = e.Embed(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, embs, 3)
+}
+
+func TestLatency(t *testing.T) {
+	t.Parallel()
+
+	e := New(func(r []string) ([]string, error) { return r, nil }, WithLatency(time.Minute))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := e.Embed(ctx, []string{"foo"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+	e := New(func(r []string) ([]string, error) { return r, nil },
+		WithRateLimit(2, time.Second), WithClock(clock))
+
+	ctx := context.Background()
+	for range 2 {
+		_, err := e.Embed(ctx, []string{"foo"})
+		assert.NoError(t, err)
+	}
+
+	now = now.Add(400 * time.Millisecond)
+	_, err := e.Embed(ctx, []string{"foo"})
+	assert.ErrorIs(t, err, ErrRateLimited)
+	var rlErr *RateLimitError
+	assert.ErrorAs(t, err, &rlErr)
+	assert.Equal(t, 600*time.Millisecond, rlErr.RetryAfter)
+
+	now = now.Add(time.Second)
+	_, err = e.Embed(ctx, []string{"foo"})
+	assert.NoError(t, err)
+}
+
+func TestMaxInputLen(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	inputs := func(r []string) ([]string, error) { return r, nil }
+
+	e := New(inputs, WithMaxInputLen(3, true))
+	embs, err := e.Embed(ctx, []string{"foobar", "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, embs[0], embs[1])
+
+	e = New(inputs, WithMaxInputLen(3, false))
+	_, err = e.Embed(ctx, []string{"foobar"})
+	assert.ErrorIs(t, err, ErrInputTooLong)
+}
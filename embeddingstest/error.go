@@ -0,0 +1,33 @@
+package embeddingstest
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInvalidInput is returned when the request inputs can't be extracted.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrRateLimited is returned when the embedder rate limit is exceeded.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrInputTooLong is returned when an input exceeds the maximum
+	// input length and the embedder does not truncate the inputs.
+	ErrInputTooLong = errors.New("input too long")
+)
+
+// RateLimitError is returned when the embedder rate limit is exceeded.
+type RateLimitError struct {
+	// RetryAfter is the time until the next call is allowed.
+	RetryAfter time.Duration
+}
+
+// Error implements errors interface.
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%v: retry after %v", ErrRateLimited, e.RetryAfter)
+}
+
+// Is reports whether the target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
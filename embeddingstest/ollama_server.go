@@ -0,0 +1,123 @@
+package embeddingstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// ollamaEmbedRequest is the Ollama embed API request schema.
+type ollamaEmbedRequest struct {
+	Input      json.RawMessage `json:"input"`
+	Model      string          `json:"model"`
+	Truncate   *bool           `json:"truncate"`
+	KeepAlive  json.RawMessage `json:"keep_alive"`
+	Dimensions int             `json:"dimensions"`
+	Options    map[string]any  `json:"options"`
+}
+
+// ollamaEmbeddingRequest is the legacy Ollama embeddings API request schema.
+type ollamaEmbeddingRequest struct {
+	Prompt    string          `json:"prompt"`
+	Model     string          `json:"model"`
+	KeepAlive json.RawMessage `json:"keep_alive"`
+	Options   map[string]any  `json:"options"`
+}
+
+type ollamaEmbedResponse struct {
+	Model           string      `json:"model"`
+	Embeddings      [][]float64 `json:"embeddings"`
+	TotalDuration   int64       `json:"total_duration"`
+	LoadDuration    int64       `json:"load_duration"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+}
+
+type ollamaEmbeddingResponse struct {
+	Embedding []float64 `json:"embedding"`
+}
+
+// NewOllamaServer starts the Ollama embeddings API emulator and returns it.
+// Use its URL with /api path as the ollama client base URL. It serves
+// both the embed API and the legacy embeddings API. Inputs longer than
+// MaxInputLen are truncated unless the request disables truncation.
+func NewOllamaServer(opts ...Option) *Server {
+	s := newServer(bearerAuth, ollamaError, opts...)
+	s.handle("POST /api/embed", s.ollamaEmbed)
+	s.handle("POST /api/embeddings", s.ollamaEmbeddings)
+	return s.start()
+}
+
+// NewLegacyOllamaServer starts the emulator of the Ollama servers which
+// only serve the legacy embeddings API and returns it. The embed API
+// requests fail with plain text 404 response like on the old servers.
+func NewLegacyOllamaServer(opts ...Option) *Server {
+	s := newServer(bearerAuth, ollamaError, opts...)
+	s.handle("POST /api/embeddings", s.ollamaEmbeddings)
+	return s.start()
+}
+
+// ollamaEmbed handles the Ollama embed request.
+func (s *Server) ollamaEmbed(_ *http.Request, body []byte) (any, error) {
+	start := time.Now()
+
+	req := new(ollamaEmbedRequest)
+	if err := decodeJSON(body, req); err != nil {
+		return nil, err
+	}
+	if req.Model == "" {
+		return nil, badRequest("model is required")
+	}
+
+	var inputs []string
+	if len(req.Input) > 0 {
+		var err error
+		if inputs, err = decodeInputs(req.Input); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &ollamaEmbedResponse{
+		Model:      req.Model,
+		Embeddings: make([][]float64, 0, len(inputs)),
+	}
+	for _, input := range inputs {
+		truncated, ok := truncate(input, s.opts.MaxInputLen)
+		if ok && req.Truncate != nil && !*req.Truncate {
+			return nil, badRequest("input length exceeds maximum context length")
+		}
+		resp.Embeddings = append(resp.Embeddings, s.vector(truncated, req.Dimensions))
+		resp.PromptEvalCount += countTokens(truncated)
+	}
+	resp.TotalDuration = time.Since(start).Nanoseconds()
+
+	return resp, nil
+}
+
+// ollamaEmbeddings handles the legacy Ollama embeddings request.
+// Empty prompt returns empty embedding.
+func (s *Server) ollamaEmbeddings(_ *http.Request, body []byte) (any, error) {
+	req := new(ollamaEmbeddingRequest)
+	if err := decodeJSON(body, req); err != nil {
+		return nil, err
+	}
+	if req.Model == "" {
+		return nil, badRequest("model is required")
+	}
+
+	resp := &ollamaEmbeddingResponse{
+		Embedding: []float64{},
+	}
+	if req.Prompt != "" {
+		prompt, _ := truncate(req.Prompt, s.opts.MaxInputLen)
+		resp.Embedding = s.vector(prompt, 0)
+	}
+
+	return resp, nil
+}
+
+// ollamaError returns the Ollama API error.
+func ollamaError(_ int, msg string) any {
+	return map[string]any{
+		"error": msg,
+	}
+}
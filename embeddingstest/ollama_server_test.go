@@ -0,0 +1,57 @@
+package embeddingstest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings/ollama"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOllamaServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	want := Vector("foo", DefaultDims, 0, true)
+
+	for _, newServer := range []func(...Option) *Server{NewOllamaServer, NewLegacyOllamaServer} {
+		s := newServer(WithMaxInputLen(3, false))
+		defer s.Close()
+
+		c := ollama.NewClient(ollama.WithBaseURL(s.URL + "/api"))
+		embs, err := c.Embed(ctx, &ollama.EmbeddingRequest{
+			Input: []string{"foo", "foobar"},
+			Model: "nomic-embed-text",
+		})
+		assert.NoError(t, err)
+		assert.Len(t, embs, 2)
+		assert.Equal(t, want, embs[0].Vector)
+		assert.Equal(t, want, embs[1].Vector)
+
+		_, err = c.Embed(ctx, &ollama.EmbeddingRequest{Input: "foo"})
+		var apiErr ollama.APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, "model is required", apiErr.ErrorMessage)
+	}
+
+	s := NewOllamaServer(WithMaxInputLen(3, false))
+	defer s.Close()
+
+	c := ollama.NewClient(ollama.WithBaseURL(s.URL + "/api"))
+	truncate := false
+	_, err := c.Embed(ctx, &ollama.EmbeddingRequest{
+		Input:    "foobar",
+		Model:    "nomic-embed-text",
+		Truncate: &truncate,
+	})
+	var apiErr ollama.APIError
+	assert.ErrorAs(t, err, &apiErr)
+
+	embs, err := c.Embed(ctx, &ollama.EmbeddingRequest{
+		Input:      "foo",
+		Model:      "nomic-embed-text",
+		Dimensions: 4,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs[0].Vector, 4)
+}
@@ -0,0 +1,181 @@
+package embeddingstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// OpenAIMaxInputs is the maximum number
+	// of inputs in a single OpenAI API request.
+	OpenAIMaxInputs = 2048
+)
+
+// openAIRequest is the OpenAI embeddings API request schema.
+type openAIRequest struct {
+	Input          json.RawMessage `json:"input"`
+	Model          string          `json:"model"`
+	User           string          `json:"user"`
+	EncodingFormat string          `json:"encoding_format"`
+	Dimensions     *int            `json:"dimensions"`
+}
+
+type openAIData struct {
+	Object    string `json:"object"`
+	Index     int    `json:"index"`
+	Embedding any    `json:"embedding"`
+}
+
+type openAIUsage struct {
+	PromptTokens int `json:"prompt_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
+
+type openAIResponse struct {
+	Object string       `json:"object"`
+	Data   []openAIData `json:"data"`
+	Model  string       `json:"model"`
+	Usage  openAIUsage  `json:"usage"`
+}
+
+// NewOpenAIServer starts the OpenAI embeddings API emulator and returns it.
+// Use its URL as the openai client base URL. Both float and base64
+// encoded embeddings are supported. Inputs longer than MaxInputLen
+// are rejected as the OpenAI API does not truncate the inputs.
+func NewOpenAIServer(opts ...Option) *Server {
+	s := newServer(bearerAuth, openAIError, opts...)
+	s.handle("POST /v1/embeddings", func(_ *http.Request, body []byte) (any, error) {
+		return s.openAIEmbeddings(body, "")
+	})
+	return s.start()
+}
+
+// NewAzureOpenAIServer starts the Azure OpenAI embeddings API emulator and returns it.
+// It serves the deployment embeddings API which requires api-version query parameter.
+// The requests are authenticated either with api-key header or with Bearer token.
+func NewAzureOpenAIServer(opts ...Option) *Server {
+	s := newServer(azureAuth, azureError, opts...)
+	s.handle("POST /openai/deployments/{deployment}/embeddings", func(r *http.Request, body []byte) (any, error) {
+		if r.URL.Query().Get("api-version") == "" {
+			return nil, &apiError{
+				status: http.StatusNotFound,
+				msg:    "Resource not found",
+			}
+		}
+		return s.openAIEmbeddings(body, r.PathValue("deployment"))
+	})
+	return s.start()
+}
+
+// openAIEmbeddings handles the OpenAI embeddings request.
+// The model is optional if the deployment is not empty.
+func (s *Server) openAIEmbeddings(body []byte, deployment string) (any, error) {
+	req := new(openAIRequest)
+	if err := decodeJSON(body, req); err != nil {
+		return nil, err
+	}
+
+	model := req.Model
+	if model == "" {
+		if deployment == "" {
+			return nil, badRequest("you must provide a model parameter")
+		}
+		model = deployment
+	}
+
+	if len(req.Input) == 0 {
+		return nil, badRequest("'input' is a required property")
+	}
+	inputs, err := decodeInputs(req.Input)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputs) == 0 || len(inputs) > OpenAIMaxInputs {
+		return nil, badRequest("'$.input' must contain between 1 and %d inputs", OpenAIMaxInputs)
+	}
+
+	var b64 bool
+	switch req.EncodingFormat {
+	case "", "float":
+	case "base64":
+		b64 = true
+	default:
+		return nil, badRequest("invalid encoding_format: %s", req.EncodingFormat)
+	}
+
+	var dims int
+	if req.Dimensions != nil {
+		if !strings.HasPrefix(model, "text-embedding-3") && deployment == "" {
+			return nil, badRequest("This model does not support specifying dimensions.")
+		}
+		if *req.Dimensions < 1 {
+			return nil, badRequest("invalid dimensions: %d", *req.Dimensions)
+		}
+		dims = *req.Dimensions
+	}
+
+	resp := &openAIResponse{
+		Object: "list",
+		Data:   make([]openAIData, 0, len(inputs)),
+		Model:  model,
+	}
+	for i, input := range inputs {
+		if input == "" {
+			return nil, badRequest("'$.input' is invalid: input %d is empty", i)
+		}
+		if _, truncated := truncate(input, s.opts.MaxInputLen); truncated {
+			return nil, badRequest("This model's maximum context length is %d, input %d is longer", s.opts.MaxInputLen, i)
+		}
+		emb, err := encodeVector(s.vector(input, dims), "float", b64)
+		if err != nil {
+			return nil, err
+		}
+		resp.Data = append(resp.Data, openAIData{
+			Object:    "embedding",
+			Index:     i,
+			Embedding: emb,
+		})
+		resp.Usage.PromptTokens += countTokens(input)
+	}
+	resp.Usage.TotalTokens = resp.Usage.PromptTokens
+
+	return resp, nil
+}
+
+// openAIError returns the OpenAI API error.
+func openAIError(status int, msg string) any {
+	errType, code := "invalid_request_error", any(nil)
+	switch {
+	case status == http.StatusUnauthorized:
+		code = "invalid_api_key"
+	case status == http.StatusTooManyRequests:
+		errType, code = "requests", "rate_limit_exceeded"
+	case status >= http.StatusInternalServerError:
+		errType = "server_error"
+	}
+	return map[string]any{
+		"error": map[string]any{
+			"message": msg,
+			"type":    errType,
+			"param":   nil,
+			"code":    code,
+		},
+	}
+}
+
+// azureAuth authenticates the requests with api-key header or Bearer token.
+func azureAuth(r *http.Request, apiKey string) bool {
+	return r.Header.Get("api-key") == apiKey || bearerAuth(r, apiKey)
+}
+
+// azureError returns the Azure OpenAI API error.
+func azureError(status int, msg string) any {
+	return map[string]any{
+		"error": map[string]any{
+			"code":    strconv.Itoa(status),
+			"message": msg,
+		},
+	}
+}
@@ -0,0 +1,231 @@
+package embeddingstest
+
+import (
+	"strings"
+
+	"github.com/milosgajdos/go-embeddings/bedrock"
+	"github.com/milosgajdos/go-embeddings/cohere"
+	"github.com/milosgajdos/go-embeddings/gemini"
+	"github.com/milosgajdos/go-embeddings/hf"
+	"github.com/milosgajdos/go-embeddings/jina"
+	"github.com/milosgajdos/go-embeddings/lexical"
+	"github.com/milosgajdos/go-embeddings/llamacpp"
+	"github.com/milosgajdos/go-embeddings/mistral"
+	"github.com/milosgajdos/go-embeddings/ollama"
+	"github.com/milosgajdos/go-embeddings/openai"
+	"github.com/milosgajdos/go-embeddings/tei"
+	"github.com/milosgajdos/go-embeddings/vertexai"
+	"github.com/milosgajdos/go-embeddings/voyage"
+)
+
+// Strings returns the inputs which are either a string or a slice of strings.
+func Strings(input any) ([]string, error) {
+	switch v := input.(type) {
+	case string:
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	}
+	return nil, ErrInvalidInput
+}
+
+// NewOpenAI creates a fake OpenAI embedder.
+func NewOpenAI(opts ...Option) *Embedder[*openai.EmbeddingRequest] {
+	return New(func(r *openai.EmbeddingRequest) ([]string, error) {
+		return Strings(r.Input)
+	}, opts...)
+}
+
+// NewCohere creates a fake Cohere embedder.
+func NewCohere(opts ...Option) *Embedder[*cohere.EmbeddingRequest] {
+	return New(func(r *cohere.EmbeddingRequest) ([]string, error) {
+		return r.Texts, nil
+	}, opts...)
+}
+
+// NewVoyage creates a fake Voyage embedder.
+func NewVoyage(opts ...Option) *Embedder[*voyage.EmbeddingRequest] {
+	return New(func(r *voyage.EmbeddingRequest) ([]string, error) {
+		return r.Input, nil
+	}, opts...)
+}
+
+// NewVoyageMultimodal creates a fake Voyage multimodal embedder.
+// Every input is embedded into a single embedding by hashing its
+// text contents along with the image URLs or data.
+func NewVoyageMultimodal(opts ...Option) *Embedder[*voyage.MultimodalEmbeddingRequest] {
+	return New(func(r *voyage.MultimodalEmbeddingRequest) ([]string, error) {
+		inputs := make([]string, 0, len(r.Inputs))
+		for _, i := range r.Inputs {
+			var input strings.Builder
+			for _, c := range i.Content {
+				input.WriteString(c.Text + c.ImageURL + c.ImageBase64)
+			}
+			inputs = append(inputs, input.String())
+		}
+		return inputs, nil
+	}, opts...)
+}
+
+// NewVoyageContextualized creates a fake Voyage contextualized chunk embedder.
+// It returns the chunk embeddings of all the documents in a single slice in
+// the order of documents and their chunks. Like the API emulator every chunk
+// is embedded along with its document, so the same chunks of different
+// documents have different embeddings.
+func NewVoyageContextualized(opts ...Option) *Embedder[*voyage.ContextualizedEmbeddingRequest] {
+	return New(func(r *voyage.ContextualizedEmbeddingRequest) ([]string, error) {
+		// nolint:prealloc
+		var inputs []string
+		for _, chunks := range r.Inputs {
+			if len(chunks) == 0 {
+				return nil, ErrInvalidInput
+			}
+			doc := strings.Join(chunks, "")
+			for _, chunk := range chunks {
+				inputs = append(inputs, doc+"\x00"+chunk)
+			}
+		}
+		return inputs, nil
+	}, opts...)
+}
+
+// NewOllama creates a fake Ollama embedder.
+// The deprecated Prompt is embedded if Input is not set.
+func NewOllama(opts ...Option) *Embedder[*ollama.EmbeddingRequest] {
+	return New(func(r *ollama.EmbeddingRequest) ([]string, error) {
+		if r.Input == nil {
+			return Strings(r.Prompt)
+		}
+		return Strings(r.Input)
+	}, opts...)
+}
+
+// NewVertexAI creates a fake VertexAI embedder.
+func NewVertexAI(opts ...Option) *Embedder[*vertexai.EmbeddingRequest] {
+	return New(func(r *vertexai.EmbeddingRequest) ([]string, error) {
+		inputs := make([]string, 0, len(r.Instances))
+		for _, i := range r.Instances {
+			inputs = append(inputs, i.Content)
+		}
+		return inputs, nil
+	}, opts...)
+}
+
+// NewVertexAIMulti creates a fake VertexAI multimodal embedder.
+// Like the API it returns an embedding for every instance modality
+// ordered by text, image and video. Images and videos are embedded
+// by hashing their URIs or data and every video is embedded into
+// a single embedding regardless of its segment config.
+func NewVertexAIMulti(opts ...Option) *Embedder[*vertexai.MultiEmbeddingRequest] {
+	return New(func(r *vertexai.MultiEmbeddingRequest) ([]string, error) {
+		// nolint:prealloc
+		var inputs []string
+		for _, i := range r.Instances {
+			if i.Text == nil && i.Image == nil && i.Video == nil {
+				return nil, ErrInvalidInput
+			}
+			if i.Text != nil {
+				inputs = append(inputs, *i.Text)
+			}
+			if i.Image != nil {
+				inputs = append(inputs, i.Image.GCSURI+i.Image.Bytes)
+			}
+			if i.Video != nil {
+				inputs = append(inputs, i.Video.GCSURI+i.Video.Bytes)
+			}
+		}
+		return inputs, nil
+	}, opts...)
+}
+
+// NewBedrock creates a fake Bedrock embedder.
+// Texts are embedded if set, otherwise InputText and InputImage
+// are embedded into a single embedding like with TitanImageV1.
+func NewBedrock(opts ...Option) *Embedder[*bedrock.Request] {
+	return New(func(r *bedrock.Request) ([]string, error) {
+		if len(r.Texts) > 0 {
+			return r.Texts, nil
+		}
+		if r.InputText == "" && len(r.InputImage) == 0 {
+			return nil, ErrInvalidInput
+		}
+		return []string{r.InputText + string(r.InputImage)}, nil
+	}, opts...)
+}
+
+// NewBedrockBatch creates a fake Bedrock batch embedder.
+// Like the client it fails if the shared request sets Texts.
+func NewBedrockBatch(opts ...Option) *Embedder[*bedrock.BatchRequest] {
+	return New(func(r *bedrock.BatchRequest) ([]string, error) {
+		if len(r.Inputs) == 0 || len(r.Texts) > 0 {
+			return nil, ErrInvalidInput
+		}
+		return r.Inputs, nil
+	}, opts...)
+}
+
+// NewGemini creates a fake Gemini embedder.
+func NewGemini(opts ...Option) *Embedder[*gemini.EmbeddingRequest] {
+	return New(func(r *gemini.EmbeddingRequest) ([]string, error) {
+		return r.Input, nil
+	}, opts...)
+}
+
+// NewMistral creates a fake Mistral embedder.
+func NewMistral(opts ...Option) *Embedder[*mistral.EmbeddingRequest] {
+	return New(func(r *mistral.EmbeddingRequest) ([]string, error) {
+		return Strings(r.Input)
+	}, opts...)
+}
+
+// NewJina creates a fake Jina embedder.
+// Image inputs are embedded by hashing the image URL or data.
+func NewJina(opts ...Option) *Embedder[*jina.EmbeddingRequest] {
+	return New(func(r *jina.EmbeddingRequest) ([]string, error) {
+		inputs := make([]string, 0, len(r.Input))
+		for _, i := range r.Input {
+			if i.Text == "" {
+				inputs = append(inputs, i.Image)
+				continue
+			}
+			inputs = append(inputs, i.Text)
+		}
+		return inputs, nil
+	}, opts...)
+}
+
+// NewTEI creates a fake Text Embeddings Inference embedder.
+func NewTEI(opts ...Option) *Embedder[*tei.EmbeddingRequest] {
+	return New(func(r *tei.EmbeddingRequest) ([]string, error) {
+		return Strings(r.Inputs)
+	}, opts...)
+}
+
+// NewTEISparse creates a fake Text Embeddings Inference sparse embedder.
+func NewTEISparse(opts ...Option) *SparseEmbedder[*tei.EmbeddingRequest] {
+	return NewSparse(func(r *tei.EmbeddingRequest) ([]string, error) {
+		return Strings(r.Inputs)
+	}, opts...)
+}
+
+// NewLlamaCpp creates a fake llama.cpp embedder.
+// NOTE: tokenized content is not supported.
+func NewLlamaCpp(opts ...Option) *Embedder[*llamacpp.EmbeddingRequest] {
+	return New(func(r *llamacpp.EmbeddingRequest) ([]string, error) {
+		return Strings(r.Content)
+	}, opts...)
+}
+
+// NewHF creates a fake Hugging Face embedder.
+func NewHF(opts ...Option) *Embedder[*hf.EmbeddingRequest] {
+	return New(func(r *hf.EmbeddingRequest) ([]string, error) {
+		return Strings(r.Inputs)
+	}, opts...)
+}
+
+// NewLexicalSparse creates a fake lexical sparse embedder.
+func NewLexicalSparse(opts ...Option) *SparseEmbedder[*lexical.EmbeddingRequest] {
+	return NewSparse(func(r *lexical.EmbeddingRequest) ([]string, error) {
+		return r.Input, nil
+	}, opts...)
+}
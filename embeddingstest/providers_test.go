@@ -0,0 +1,171 @@
+package embeddingstest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/milosgajdos/go-embeddings/bedrock"
+	"github.com/milosgajdos/go-embeddings/cohere"
+	"github.com/milosgajdos/go-embeddings/gemini"
+	"github.com/milosgajdos/go-embeddings/hf"
+	"github.com/milosgajdos/go-embeddings/jina"
+	"github.com/milosgajdos/go-embeddings/lexical"
+	"github.com/milosgajdos/go-embeddings/llamacpp"
+	"github.com/milosgajdos/go-embeddings/mistral"
+	"github.com/milosgajdos/go-embeddings/ollama"
+	"github.com/milosgajdos/go-embeddings/openai"
+	"github.com/milosgajdos/go-embeddings/tei"
+	"github.com/milosgajdos/go-embeddings/vertexai"
+	"github.com/milosgajdos/go-embeddings/voyage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviders(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	foo := Vector("foo", DefaultDims, 0, true)
+	text := "foo"
+
+	testCases := []struct {
+		name  string
+		embed func() ([]*embeddings.Embedding, error)
+		want  int
+	}{
+		{"openai", func() ([]*embeddings.Embedding, error) {
+			return NewOpenAI().Embed(ctx, &openai.EmbeddingRequest{Input: "foo"})
+		}, 1},
+		{"cohere", func() ([]*embeddings.Embedding, error) {
+			return NewCohere().Embed(ctx, &cohere.EmbeddingRequest{Texts: []string{"foo", "bar"}})
+		}, 2},
+		{"voyage", func() ([]*embeddings.Embedding, error) {
+			return NewVoyage().Embed(ctx, &voyage.EmbeddingRequest{Input: []string{"foo"}})
+		}, 1},
+		{"voyage multimodal", func() ([]*embeddings.Embedding, error) {
+			return NewVoyageMultimodal().Embed(ctx, &voyage.MultimodalEmbeddingRequest{
+				Inputs: []voyage.MultimodalInput{
+					voyage.NewMultimodalInput(voyage.NewTextContent("foo")),
+					voyage.NewMultimodalInput(voyage.NewTextContent("foo"), voyage.NewImageURLContent("https://example.com/foo.png")),
+				},
+			})
+		}, 2},
+		{"ollama", func() ([]*embeddings.Embedding, error) {
+			return NewOllama().Embed(ctx, &ollama.EmbeddingRequest{Prompt: "foo"})
+		}, 1},
+		{"vertexai", func() ([]*embeddings.Embedding, error) {
+			return NewVertexAI().Embed(ctx, &vertexai.EmbeddingRequest{Instances: []vertexai.Instance{{Content: "foo"}}})
+		}, 1},
+		{"vertexai multi", func() ([]*embeddings.Embedding, error) {
+			return NewVertexAIMulti().Embed(ctx, &vertexai.MultiEmbeddingRequest{
+				Instances: []vertexai.MultiInstance{
+					{Text: &text, Image: vertexai.NewImageGCS("gs://bucket/foo.png")},
+					{Video: vertexai.NewVideoGCS("gs://bucket/foo.mp4", nil)},
+				},
+			})
+		}, 3},
+		{"bedrock", func() ([]*embeddings.Embedding, error) {
+			return NewBedrock().Embed(ctx, &bedrock.Request{InputText: "foo"})
+		}, 1},
+		{"bedrock batch", func() ([]*embeddings.Embedding, error) {
+			return NewBedrockBatch().Embed(ctx, &bedrock.BatchRequest{Inputs: []string{"foo", "bar"}})
+		}, 2},
+		{"gemini", func() ([]*embeddings.Embedding, error) {
+			return NewGemini().Embed(ctx, &gemini.EmbeddingRequest{Input: []string{"foo"}})
+		}, 1},
+		{"mistral", func() ([]*embeddings.Embedding, error) {
+			return NewMistral().Embed(ctx, &mistral.EmbeddingRequest{Input: []string{"foo"}})
+		}, 1},
+		{"jina", func() ([]*embeddings.Embedding, error) {
+			return NewJina().Embed(ctx, &jina.EmbeddingRequest{Input: jina.TextInputs("foo")})
+		}, 1},
+		{"tei", func() ([]*embeddings.Embedding, error) {
+			return NewTEI().Embed(ctx, &tei.EmbeddingRequest{Inputs: "foo"})
+		}, 1},
+		{"llamacpp", func() ([]*embeddings.Embedding, error) {
+			return NewLlamaCpp().Embed(ctx, &llamacpp.EmbeddingRequest{Content: "foo"})
+		}, 1},
+		{"hf", func() ([]*embeddings.Embedding, error) {
+			return NewHF().Embed(ctx, &hf.EmbeddingRequest{Inputs: "foo"})
+		}, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			embs, err := tc.embed()
+			assert.NoError(t, err)
+			assert.Len(t, embs, tc.want)
+			assert.Equal(t, foo, embs[0].Vector)
+		})
+	}
+}
+
+func TestVoyageContextualized(t *testing.T) {
+	t.Parallel()
+
+	embs, err := NewVoyageContextualized().Embed(context.Background(), &voyage.ContextualizedEmbeddingRequest{
+		Inputs: [][]string{{"foo", "bar"}, {"foo"}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 3)
+	assert.Equal(t, Vector("foobar\x00foo", DefaultDims, 0, true), embs[0].Vector)
+	assert.Equal(t, Vector("foobar\x00bar", DefaultDims, 0, true), embs[1].Vector)
+	assert.NotEqual(t, embs[0].Vector, embs[2].Vector)
+}
+
+func TestSparseProviders(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	foo := SparseVector("foo foo bar", DefaultDims, 0, true)
+
+	testCases := []struct {
+		name  string
+		embed func() ([]*embeddings.SparseEmbedding, error)
+		want  int
+	}{
+		{"tei", func() ([]*embeddings.SparseEmbedding, error) {
+			return NewTEISparse().SparseEmbed(ctx, &tei.EmbeddingRequest{Inputs: []string{"foo foo bar", "baz"}})
+		}, 2},
+		{"lexical", func() ([]*embeddings.SparseEmbedding, error) {
+			return NewLexicalSparse().SparseEmbed(ctx, &lexical.EmbeddingRequest{Input: []string{"foo foo bar"}})
+		}, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			embs, err := tc.embed()
+			assert.NoError(t, err)
+			assert.Len(t, embs, tc.want)
+			assert.Equal(t, foo, embs[0])
+		})
+	}
+
+	var _ embeddings.SparseEmbedder[*tei.EmbeddingRequest] = NewTEISparse()
+	var _ embeddings.SparseEmbedder[*lexical.EmbeddingRequest] = NewLexicalSparse()
+}
+
+func TestInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewBedrock().Embed(context.Background(), &bedrock.Request{})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = NewBedrockBatch().Embed(context.Background(), &bedrock.BatchRequest{
+		Inputs:  []string{"foo"},
+		Request: bedrock.Request{Texts: []string{"foo"}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = NewVoyageContextualized().Embed(context.Background(), &voyage.ContextualizedEmbeddingRequest{
+		Inputs: [][]string{{}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = NewVertexAIMulti().Embed(context.Background(), &vertexai.MultiEmbeddingRequest{
+		Instances: []vertexai.MultiInstance{{}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
@@ -0,0 +1,386 @@
+package embeddingstest
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// malformedJSON is returned by the API emulators
+// when the Fault requests malformed response.
+const malformedJSON = `{"data":[{"embedding":[0.1,`
+
+// Fault is a failure injected into a single API call.
+type Fault struct {
+	// Status is the HTTP status code of the API error e.g. 429 or 503.
+	// No error is returned if Status is 0.
+	Status int
+	// RetryAfter sets the Retry-After header of the API error.
+	RetryAfter time.Duration
+	// Malformed makes the API return malformed JSON with 200 status.
+	Malformed bool
+	// Delay delays the API response.
+	Delay time.Duration
+}
+
+// Request is an API request received by an API emulator.
+type Request struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+}
+
+// Server is an API emulator serving the HTTP API of an embeddings provider.
+// It validates the API requests and returns deterministic embeddings, see Vector.
+// The API errors are returned in the provider API error schema.
+//
+// The options configure the emulator: Latency delays every response,
+// RateLimit makes the API return 429 with Retry-After header and
+// MaxInputLen is the model context length in runes. The longer
+// inputs are truncated or rejected depending on the API request.
+type Server struct {
+	*httptest.Server
+	opts    Options
+	mux     *http.ServeMux
+	auth    func(r *http.Request, apiKey string) bool
+	errBody func(status int, msg string) any
+	inj     *injector
+	mu      sync.Mutex
+	reqs    []*Request
+}
+
+// handlerFunc handles the API request body
+// and returns the API response or apiError.
+type handlerFunc func(r *http.Request, body []byte) (any, error)
+
+// apiError is an API error returned by handlerFunc.
+type apiError struct {
+	status int
+	msg    string
+}
+
+// Error implements errors interface.
+func (e *apiError) Error() string {
+	return e.msg
+}
+
+// badRequest returns the API error with 400 status.
+func badRequest(format string, args ...any) error {
+	return &apiError{
+		status: http.StatusBadRequest,
+		msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+// newServer creates a new unstarted API emulator.
+func newServer(auth func(*http.Request, string) bool, errBody func(int, string) any, opts ...Option) *Server {
+	options := newOptions(opts...)
+
+	return &Server{
+		opts:    options,
+		mux:     http.NewServeMux(),
+		auth:    auth,
+		errBody: errBody,
+		inj:     newInjector(options),
+	}
+}
+
+// start starts the API emulator and returns it.
+func (s *Server) start() *Server {
+	s.Server = httptest.NewServer(s.mux)
+	return s
+}
+
+// Inject queues the faults injected into the following API calls, one fault per call.
+// The calls made after the injected faults are exhausted use the emulator options.
+func (s *Server) Inject(faults ...Fault) *Server {
+	s.inj.inject(faults...)
+	return s
+}
+
+// Requests returns the API requests received by the emulator.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reqs := make([]*Request, len(s.reqs))
+	copy(reqs, s.reqs)
+	return reqs
+}
+
+// Reset clears the received requests, the injected faults and the rate limiter.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	s.reqs = nil
+	s.mu.Unlock()
+	s.inj.reset()
+}
+
+// handle registers the API handler for the given pattern.
+func (s *Server) handle(pattern string, h handlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		s.reqs = append(s.reqs, &Request{
+			Method: r.Method,
+			URL:    r.URL,
+			Header: r.Header.Clone(),
+			Body:   body,
+		})
+		s.mu.Unlock()
+
+		fault, retryAfter, limited := s.inj.next()
+
+		if err := sleep(r.Context(), fault.Delay); err != nil {
+			return
+		}
+
+		switch {
+		case limited:
+			w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+			s.writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		case fault.Status != 0:
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", retryAfterSeconds(fault.RetryAfter))
+			}
+			s.writeError(w, fault.Status, http.StatusText(fault.Status))
+			return
+		case fault.Malformed:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, malformedJSON)
+			return
+		}
+
+		if s.opts.APIKey != "" && !s.auth(r, s.opts.APIKey) {
+			s.writeError(w, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+
+		resp, err := h(r, body)
+		if err != nil {
+			var apiErr *apiError
+			if errors.As(err, &apiErr) {
+				s.writeError(w, apiErr.status, apiErr.msg)
+				return
+			}
+			s.writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+// writeError writes the API error in the provider API error schema.
+func (s *Server) writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, s.errBody(status, msg))
+}
+
+// vector returns the embedding of the text with the given
+// number of dimensions or with Dims if dims is not positive.
+func (s *Server) vector(text string, dims int) []float64 {
+	if dims <= 0 {
+		dims = s.opts.Dims
+	}
+	return Vector(text, dims, s.opts.Seed, s.opts.Normalize)
+}
+
+// writeJSON writes the JSON encoded v with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+}
+
+// bearerAuth authenticates the requests with Bearer token.
+func bearerAuth(r *http.Request, apiKey string) bool {
+	return r.Header.Get("Authorization") == "Bearer "+apiKey
+}
+
+// retryAfterSeconds formats d as Retry-After header seconds.
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(max(1, int(math.Ceil(d.Seconds()))))
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// injector queues the injected faults and enforces the rate limit.
+type injector struct {
+	mu      sync.Mutex
+	latency time.Duration
+	faults  []Fault
+	rl      rateLimiter
+}
+
+// newInjector creates an injector configured by the options.
+func newInjector(opts Options) *injector {
+	return &injector{
+		latency: opts.Latency,
+		rl:      newRateLimiter(opts),
+	}
+}
+
+// inject queues the faults.
+func (i *injector) inject(faults ...Fault) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults = append(i.faults, faults...)
+}
+
+// next returns the fault of the next call. If the call is
+// rate limited it returns the time until the next allowed call.
+func (i *injector) next() (Fault, time.Duration, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if retryAfter, ok := i.rl.allow(); !ok {
+		return Fault{Delay: i.latency}, retryAfter, true
+	}
+
+	if len(i.faults) > 0 {
+		fault := i.faults[0]
+		i.faults = i.faults[1:]
+		return fault, 0, false
+	}
+
+	return Fault{Delay: i.latency}, 0, false
+}
+
+// reset clears the faults and the rate limiter.
+func (i *injector) reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults = nil
+	i.rl.reset()
+}
+
+// decodeJSON decodes the request body into v.
+// Unknown fields are rejected.
+func decodeJSON(body []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return badRequest("invalid request body: %v", err)
+	}
+	return nil
+}
+
+// decodeInputs decodes the inputs which are either a string, a slice
+// of strings, a slice of tokens or a slice of token slices.
+// Tokenized inputs are embedded by their string representation.
+func decodeInputs(raw json.RawMessage) ([]string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []string{s}, nil
+	}
+	var ss []string
+	if err := json.Unmarshal(raw, &ss); err == nil {
+		return ss, nil
+	}
+	var tokens []int
+	if err := json.Unmarshal(raw, &tokens); err == nil {
+		return []string{fmt.Sprint(tokens)}, nil
+	}
+	var batch [][]int
+	if err := json.Unmarshal(raw, &batch); err == nil {
+		inputs := make([]string, 0, len(batch))
+		for _, tokens := range batch {
+			inputs = append(inputs, fmt.Sprint(tokens))
+		}
+		return inputs, nil
+	}
+	return nil, badRequest("invalid input: %s", raw)
+}
+
+// countTokens approximates the number of tokens in the text.
+func countTokens(text string) int {
+	return len(strings.Fields(text))
+}
+
+// encodeVector encodes the vector in the given data type. Floats are
+// base64 encoded as little-endian float32 values like the provider APIs
+// do and the integer data types are base64 encoded as bytes if b64 is true.
+// The binary data types pack the signs of the vector values
+// into bytes; binary is offset by -128 to fit into int8.
+func encodeVector(vec []float64, dtype string, b64 bool) (any, error) {
+	var ints []int
+	switch dtype {
+	case "", "float":
+		if !b64 {
+			return vec, nil
+		}
+		buf := make([]byte, 4*len(vec))
+		for i, f := range vec {
+			binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(float32(f)))
+		}
+		return base64.StdEncoding.EncodeToString(buf), nil
+	case "int8":
+		for _, f := range vec {
+			ints = append(ints, int(math.Round(max(-1, min(1, f))*127)))
+		}
+	case "uint8":
+		for _, f := range vec {
+			ints = append(ints, int(math.Round((max(-1, min(1, f))+1)*127.5)))
+		}
+	case "binary", "ubinary":
+		for i := 0; i < len(vec); i += 8 {
+			var b int
+			for j := i; j < i+8; j++ {
+				b <<= 1
+				if j < len(vec) && vec[j] > 0 {
+					b |= 1
+				}
+			}
+			if dtype == "binary" {
+				b -= 128
+			}
+			ints = append(ints, b)
+		}
+	default:
+		return nil, badRequest("unsupported data type: %s", dtype)
+	}
+
+	if !b64 {
+		return ints, nil
+	}
+	buf := make([]byte, len(ints))
+	for i, n := range ints {
+		// nolint:gosec
+		buf[i] = byte(n)
+	}
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
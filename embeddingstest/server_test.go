@@ -0,0 +1,192 @@
+package embeddingstest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/milosgajdos/go-embeddings/openai"
+	"github.com/stretchr/testify/assert"
+)
+
+func post(t *testing.T, url string, header http.Header, body string) (*http.Response, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	assert.NoError(t, err)
+	req.Header = header
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	var data map[string]any
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&data))
+	return resp, data
+}
+
+func TestOpenAIServer(t *testing.T) {
+	t.Parallel()
+
+	s := NewOpenAIServer(WithAPIKey("key"), WithDims(16))
+	defer s.Close()
+
+	ctx := context.Background()
+	want := Vector("foo", 16, 0, true)
+
+	for _, enc := range []openai.EncodingFormat{openai.EncodingFloat, openai.EncodingBase64} {
+		c := openai.NewClient(openai.WithBaseURL(s.URL), openai.WithAPIKey("key"))
+		embs, err := c.Embed(ctx, &openai.EmbeddingRequest{
+			Input:          []string{"foo", "bar"},
+			Model:          openai.TextSmallV3,
+			EncodingFormat: enc,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, embs, 2)
+		// NOTE: base64 embeddings are encoded as float32
+		assert.InDeltaSlice(t, want, embs[0].Vector, 1e-6)
+	}
+
+	c := openai.NewClient(openai.WithBaseURL(s.URL), openai.WithAPIKey("key"))
+	embs, err := c.Embed(ctx, &openai.EmbeddingRequest{
+		Input:          "foo",
+		Model:          openai.TextSmallV3,
+		EncodingFormat: openai.EncodingFloat,
+		Dims:           4,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs[0].Vector, 4)
+
+	_, err = c.Embed(ctx, &openai.EmbeddingRequest{
+		Input:          "foo",
+		Model:          openai.TextAdaV2,
+		EncodingFormat: openai.EncodingFloat,
+		Dims:           4,
+	})
+	var apiErr openai.APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "invalid_request_error", apiErr.Err.Type)
+
+	c = openai.NewClient(openai.WithBaseURL(s.URL), openai.WithAPIKey("invalid"))
+	_, err = c.Embed(ctx, &openai.EmbeddingRequest{Input: "foo", Model: openai.TextSmallV3, EncodingFormat: openai.EncodingFloat})
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "invalid_api_key", apiErr.Err.Code)
+
+	assert.Len(t, s.Requests(), 5)
+	s.Reset()
+	assert.Empty(t, s.Requests())
+}
+
+func TestServerFaults(t *testing.T) {
+	t.Parallel()
+
+	s := NewOpenAIServer()
+	defer s.Close()
+
+	header := http.Header{"Content-Type": {"application/json"}}
+	body := `{"input":"foo","model":"text-embedding-3-small"}`
+
+	s.Inject(
+		Fault{Status: http.StatusTooManyRequests, RetryAfter: 1500 * time.Millisecond},
+		Fault{Status: http.StatusServiceUnavailable},
+	)
+
+	resp, data := post(t, s.URL+"/v1/embeddings", header, body)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	assert.Equal(t, "rate_limit_exceeded", data["error"].(map[string]any)["code"])
+
+	resp, _ = post(t, s.URL+"/v1/embeddings", header, body)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Retry-After"))
+
+	resp, _ = post(t, s.URL+"/v1/embeddings", header, body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, _ = post(t, s.URL+"/v1/embeddings", header, `{"input":"foo","model":"text-embedding-3-small","unknown":1}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	ctx := context.Background()
+	c := openai.NewClient(openai.WithBaseURL(s.URL))
+	req := &openai.EmbeddingRequest{Input: "foo", Model: openai.TextSmallV3, EncodingFormat: openai.EncodingFloat}
+
+	s.Inject(Fault{Malformed: true})
+	_, err := c.Embed(ctx, req)
+	assert.Error(t, err)
+
+	s.Inject(Fault{Delay: time.Minute})
+	tctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = c.Embed(tctx, req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestServerRateLimit(t *testing.T) {
+	t.Parallel()
+
+	s := NewOpenAIServer(WithRateLimit(1, time.Minute))
+	defer s.Close()
+
+	header := http.Header{"Content-Type": {"application/json"}}
+	body := `{"input":"foo","model":"text-embedding-3-small"}`
+
+	resp, _ := post(t, s.URL+"/v1/embeddings", header, body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, _ = post(t, s.URL+"/v1/embeddings", header, body)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+}
+
+func TestAzureOpenAIServer(t *testing.T) {
+	t.Parallel()
+
+	s := NewAzureOpenAIServer(WithAPIKey("key"))
+	defer s.Close()
+
+	url := s.URL + "/openai/deployments/ada/embeddings"
+	body := `{"input":["foo"]}`
+
+	resp, data := post(t, url+"?api-version=2024-02-01", http.Header{"api-key": {"key"}}, body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "ada", data["model"])
+	assert.Len(t, data["data"], 1)
+
+	resp, _ = post(t, url+"?api-version=2024-02-01", http.Header{"Authorization": {"Bearer key"}}, body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, data = post(t, url+"?api-version=2024-02-01", http.Header{"api-key": {"invalid"}}, body)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "401", data["error"].(map[string]any)["code"])
+
+	resp, _ = post(t, url, http.Header{"api-key": {"key"}}, body)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestEncodeVector(t *testing.T) {
+	t.Parallel()
+
+	vec := []float64{1, -1, 0.5, -0.5, 0, 0.1, 0.2, -0.3, 0.4}
+
+	testCases := []struct {
+		dtype string
+		b64   bool
+		want  any
+	}{
+		{"int8", false, []int{127, -127, 64, -64, 0, 13, 25, -38, 51}},
+		{"uint8", false, []int{255, 0, 191, 64, 128, 140, 153, 89, 179}},
+		{"ubinary", false, []int{0b10100110, 0b10000000}},
+		{"binary", false, []int{0b10100110 - 128, 0}},
+		{"ubinary", true, "poA="},
+		{"float", true, "AACAPwAAgL8AAAA/AAAAvwAAAADNzMw9zcxMPpqZmb7NzMw+"},
+	}
+
+	for _, tc := range testCases {
+		got, err := encodeVector(vec, tc.dtype, tc.b64)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, got, tc.dtype)
+	}
+
+	_, err := encodeVector(vec, "foo", false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package embeddingstest
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/milosgajdos/go-embeddings"
+)
+
+// SparseEmbedder is a deterministic fake sparse embedder.
+// It's safe for concurrent use.
+type SparseEmbedder[T any] struct {
+	e *Embedder[T]
+}
+
+// NewSparse creates a new fake sparse embedder and returns it.
+// The inputs func extracts the texts to embed from the requests.
+// Every word of the text is hashed into one of Dims indices,
+// so the texts which share words have overlapping embeddings.
+// By default the embeddings are normalized to unit length.
+func NewSparse[T any](inputs func(T) ([]string, error), opts ...Option) *SparseEmbedder[T] {
+	return &SparseEmbedder[T]{
+		e: New(inputs, opts...),
+	}
+}
+
+// Script queues the steps which script the following calls, one step per call.
+// The calls made after the scripted steps are exhausted use the embedder options.
+func (s *SparseEmbedder[T]) Script(steps ...Step) *SparseEmbedder[T] {
+	s.e.Script(steps...)
+	return s
+}
+
+// Calls returns the requests the embedder was called with.
+func (s *SparseEmbedder[T]) Calls() []T {
+	return s.e.Calls()
+}
+
+// Reset clears the recorded calls, the scripted steps and the rate limiter.
+func (s *SparseEmbedder[T]) Reset() {
+	s.e.Reset()
+}
+
+// SparseEmbed returns deterministic sparse embeddings for every input in the request.
+func (s *SparseEmbedder[T]) SparseEmbed(ctx context.Context, req T) ([]*embeddings.SparseEmbedding, error) {
+	inputs, err := s.e.texts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := s.e.opts
+	embs := make([]*embeddings.SparseEmbedding, 0, len(inputs))
+	for _, input := range inputs {
+		embs = append(embs, SparseVector(input, opts.Dims, opts.Seed, opts.Normalize))
+	}
+
+	return embs, nil
+}
+
+// SparseVector returns a deterministic sparse vector with indices
+// in the range [0, dims). Every word of the text is hashed with
+// seed into an index whose value counts the word occurrences.
+// The vector is normalized to unit length if normalize is true.
+// The vector is empty if dims is not positive.
+func SparseVector(text string, dims int, seed uint64, normalize bool) *embeddings.SparseEmbedding {
+	vals := make(map[int]float64)
+	if dims <= 0 {
+		return embeddings.NewSparseEmbedding(vals)
+	}
+	for _, word := range strings.Fields(text) {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(word))
+		// nolint:gosec
+		r := rand.New(rand.NewPCG(h.Sum64(), seed))
+		vals[r.IntN(dims)]++
+	}
+
+	if normalize {
+		var norm float64
+		for _, v := range vals {
+			norm += v * v
+		}
+		norm = math.Sqrt(norm)
+		for i := range vals {
+			vals[i] /= norm
+		}
+	}
+
+	return embeddings.NewSparseEmbedding(vals)
+}
@@ -0,0 +1,47 @@
+package embeddingstest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings/tei"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparseVector(t *testing.T) {
+	t.Parallel()
+
+	v := SparseVector("foo bar foo", 1024, 0, true)
+	assert.NoError(t, v.Validate())
+	assert.Equal(t, 2, v.Len())
+	assert.InDelta(t, 1.0, v.Dot(*v), 1e-12)
+	assert.Equal(t, v, SparseVector("foo bar foo", 1024, 0, true))
+	assert.Greater(t, v.Dot(*SparseVector("foo", 1024, 0, true)), 0.0)
+
+	v = SparseVector("foo bar foo", 1024, 0, false)
+	assert.ElementsMatch(t, []float64{1, 2}, v.Values)
+
+	assert.Zero(t, SparseVector("", 1024, 0, true).Len())
+	assert.Zero(t, SparseVector("foo", 0, 0, true).Len())
+}
+
+func TestSparseEmbed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	errFoo := errors.New("foo")
+	e := NewTEISparse(WithDims(1024)).Script(Step{Err: errFoo})
+	req := &tei.EmbeddingRequest{Inputs: []string{"foo", "bar"}}
+
+	_, err := e.SparseEmbed(ctx, req)
+	assert.ErrorIs(t, err, errFoo)
+
+	embs, err := e.SparseEmbed(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, embs, 2)
+	assert.Len(t, e.Calls(), 2)
+
+	e.Reset()
+	assert.Empty(t, e.Calls())
+}
@@ -0,0 +1,175 @@
+package embeddingstest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/milosgajdos/go-embeddings/vertexai"
+)
+
+// vertexMaxInstances is the instance limit of the
+// models and endpoints with unknown limits.
+const vertexMaxInstances = 250
+
+var vertexTaskTypes = []vertexai.TaskType{
+	"",
+	vertexai.RetrQueryTask,
+	vertexai.RetrDocTask,
+	vertexai.SemanticSimTask,
+	vertexai.ClassificationTask,
+	vertexai.ClusteringTask,
+	vertexai.QATask,
+	vertexai.FactVerifTask,
+	vertexai.CodeRetrQueryTask,
+}
+
+type vertexInstance struct {
+	TaskType vertexai.TaskType `json:"task_type"`
+	Title    string            `json:"title"`
+	Content  string            `json:"content"`
+}
+
+type vertexParams struct {
+	AutoTruncate         *bool `json:"autoTruncate"`
+	OutputDimensionality int   `json:"outputDimensionality"`
+}
+
+// vertexRequest is the Vertex AI text embeddings prediction request schema.
+type vertexRequest struct {
+	Instances  []vertexInstance `json:"instances"`
+	Parameters *vertexParams    `json:"parameters"`
+}
+
+type vertexStatistics struct {
+	TokenCount int  `json:"token_count"`
+	Truncated  bool `json:"truncated"`
+}
+
+type vertexEmbeddings struct {
+	Values     []float64        `json:"values"`
+	Statistics vertexStatistics `json:"statistics"`
+}
+
+type vertexPrediction struct {
+	Embeddings vertexEmbeddings `json:"embeddings"`
+}
+
+type vertexResponse struct {
+	Predictions []vertexPrediction `json:"predictions"`
+	Metadata    map[string]any     `json:"metadata"`
+}
+
+// NewVertexAIServer starts the Vertex AI text embeddings API emulator and returns it.
+// Use its URL with /v1/projects path as the vertexai client base URL.
+// It serves the predictions of both the Google published models and
+// the deployed endpoints. The published model requests are validated
+// against the vertexai.Model limits. Inputs longer than MaxInputLen are
+// truncated unless the request disables autoTruncate.
+func NewVertexAIServer(opts ...Option) *Server {
+	s := newServer(bearerAuth, vertexError, opts...)
+	s.handle("POST /v1/projects/{project}/locations/{location}/publishers/{publisher}/models/{model}", func(r *http.Request, body []byte) (any, error) {
+		model, ok := strings.CutSuffix(r.PathValue("model"), ":predict")
+		if !ok {
+			return nil, &apiError{status: http.StatusNotFound, msg: "method not found"}
+		}
+		limits, ok := vertexai.Model(model).Limits()
+		if !ok {
+			limits = vertexai.Limits{MaxInstances: vertexMaxInstances}
+		}
+		return s.vertexPredict(body, limits)
+	})
+	s.handle("POST /v1/projects/{project}/locations/{location}/endpoints/{endpoint}", func(r *http.Request, body []byte) (any, error) {
+		if !strings.HasSuffix(r.PathValue("endpoint"), ":predict") {
+			return nil, &apiError{status: http.StatusNotFound, msg: "method not found"}
+		}
+		return s.vertexPredict(body, vertexai.Limits{MaxInstances: vertexMaxInstances})
+	})
+	return s.start()
+}
+
+// vertexPredict handles the Vertex AI text embeddings prediction request.
+// Output dimensionality is not validated if limits MaxDims is 0.
+func (s *Server) vertexPredict(body []byte, limits vertexai.Limits) (any, error) {
+	req := new(vertexRequest)
+	if err := decodeJSON(body, req); err != nil {
+		return nil, err
+	}
+	if len(req.Instances) == 0 || len(req.Instances) > limits.MaxInstances {
+		return nil, badRequest("instances must contain between 1 and %d instances", limits.MaxInstances)
+	}
+
+	params := vertexParams{}
+	if req.Parameters != nil {
+		params = *req.Parameters
+	}
+	if dims := params.OutputDimensionality; dims != 0 && limits.MaxDims != 0 && (dims < limits.MinDims || dims > limits.MaxDims) {
+		return nil, badRequest("outputDimensionality must be between %d and %d", limits.MinDims, limits.MaxDims)
+	}
+	autoTruncate := params.AutoTruncate == nil || *params.AutoTruncate
+
+	resp := &vertexResponse{
+		Predictions: make([]vertexPrediction, 0, len(req.Instances)),
+	}
+	var chars int
+	for i, inst := range req.Instances {
+		if !slices.Contains(vertexTaskTypes, inst.TaskType) {
+			return nil, badRequest("instance %d has invalid task_type: %s", i, inst.TaskType)
+		}
+		if inst.Title != "" && inst.TaskType != vertexai.RetrDocTask {
+			return nil, badRequest("instance %d title requires %s task_type", i, vertexai.RetrDocTask)
+		}
+		if inst.Content == "" {
+			return nil, badRequest("instance %d content is empty", i)
+		}
+		content, truncated := truncate(inst.Content, s.opts.MaxInputLen)
+		if truncated && !autoTruncate {
+			return nil, badRequest("instance %d content exceeds the token limit %d", i, s.opts.MaxInputLen)
+		}
+		resp.Predictions = append(resp.Predictions, vertexPrediction{
+			Embeddings: vertexEmbeddings{
+				Values: s.vector(content, params.OutputDimensionality),
+				Statistics: vertexStatistics{
+					TokenCount: countTokens(content),
+					Truncated:  truncated,
+				},
+			},
+		})
+		chars += len([]rune(content))
+	}
+	resp.Metadata = map[string]any{
+		"billableCharacterCount": chars,
+	}
+
+	return resp, nil
+}
+
+// vertexError returns the Google API error.
+func vertexError(status int, msg string) any {
+	var code string
+	switch status {
+	case http.StatusBadRequest:
+		code = "INVALID_ARGUMENT"
+	case http.StatusUnauthorized:
+		code = "UNAUTHENTICATED"
+	case http.StatusForbidden:
+		code = "PERMISSION_DENIED"
+	case http.StatusNotFound:
+		code = "NOT_FOUND"
+	case http.StatusTooManyRequests:
+		code = "RESOURCE_EXHAUSTED"
+	case http.StatusServiceUnavailable:
+		code = "UNAVAILABLE"
+	case http.StatusGatewayTimeout:
+		code = "DEADLINE_EXCEEDED"
+	default:
+		code = "INTERNAL"
+	}
+	return map[string]any{
+		"error": map[string]any{
+			"code":    status,
+			"message": msg,
+			"status":  code,
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package embeddingstest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings/vertexai"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVertexAIServer(t *testing.T) {
+	t.Parallel()
+
+	s := NewVertexAIServer(WithAPIKey("token"), WithMaxInputLen(3, false))
+	defer s.Close()
+
+	ctx := context.Background()
+	autoTruncate, noTruncate := true, false
+	c := vertexai.NewClient(
+		vertexai.WithBaseURL(s.URL+"/v1/projects"),
+		vertexai.WithToken("token"),
+		vertexai.WithProjectID("project"),
+		vertexai.WithLocation("us-central1"),
+		vertexai.WithModelID(vertexai.EmbedTextV5.String()),
+	)
+
+	embs, err := c.Embed(ctx, &vertexai.EmbeddingRequest{
+		Instances: []vertexai.Instance{
+			{TaskType: vertexai.RetrDocTask, Title: "foo", Content: "foo"},
+			{TaskType: vertexai.RetrQueryTask, Content: "bar"},
+		},
+		Params: vertexai.Params{AutoTruncate: &autoTruncate, OutputDimensionality: 16},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 2)
+	assert.Equal(t, Vector("foo", 16, 0, true), embs[0].Vector)
+
+	testCases := []struct {
+		name string
+		req  *vertexai.EmbeddingRequest
+	}{
+		{"title", &vertexai.EmbeddingRequest{Instances: []vertexai.Instance{{TaskType: vertexai.RetrQueryTask, Title: "foo", Content: "foo"}}}},
+		{"empty", &vertexai.EmbeddingRequest{Instances: []vertexai.Instance{{Content: ""}}}},
+		{"too long", &vertexai.EmbeddingRequest{Instances: []vertexai.Instance{{Content: "foobar"}}, Params: vertexai.Params{AutoTruncate: &noTruncate}}},
+	}
+
+	for _, tc := range testCases {
+		_, err := c.Embed(ctx, tc.req)
+		var apiErr vertexai.APIError
+		assert.ErrorAs(t, err, &apiErr, tc.name)
+		assert.Equal(t, "INVALID_ARGUMENT", apiErr.RespError.Status, tc.name)
+	}
+
+	c = vertexai.NewClient(
+		vertexai.WithBaseURL(s.URL+"/v1/projects"),
+		vertexai.WithToken("token"),
+		vertexai.WithProjectID("project"),
+		vertexai.WithLocation("us-central1"),
+		vertexai.WithEndpointID("1234"),
+	)
+	embs, err = c.Embed(ctx, &vertexai.EmbeddingRequest{
+		Instances: []vertexai.Instance{{Content: "foobar"}},
+		Params:    vertexai.Params{AutoTruncate: &autoTruncate},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Vector("foo", DefaultDims, 0, true), embs[0].Vector)
+
+	s.Inject(Fault{Status: http.StatusTooManyRequests})
+	_, err = c.Embed(ctx, &vertexai.EmbeddingRequest{Instances: []vertexai.Instance{{Content: "foo"}}})
+	var apiErr vertexai.APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "RESOURCE_EXHAUSTED", apiErr.RespError.Status)
+}
@@ -0,0 +1,292 @@
+package embeddingstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	// VoyageMaxInputs is the maximum number
+	// of inputs in a single Voyage API request.
+	VoyageMaxInputs = 1000
+	// voyageImagePixels is the number of pixels billed per image.
+	voyageImagePixels = 1024 * 1024
+	// voyagePixelsPerToken is the number of image pixels billed as a token.
+	voyagePixelsPerToken = 560
+)
+
+var voyageInputTypes = []string{"", "None", "query", "document"}
+
+// voyageRequest is the Voyage embeddings API request schema.
+type voyageRequest struct {
+	Input           json.RawMessage `json:"input"`
+	Model           string          `json:"model"`
+	InputType       string          `json:"input_type"`
+	Truncation      *bool           `json:"truncation"`
+	EncodingFormat  string          `json:"encoding_format"`
+	OutputDimension int             `json:"output_dimension"`
+	OutputDType     string          `json:"output_dtype"`
+}
+
+type voyageContent struct {
+	Type        string `json:"type"`
+	Text        string `json:"text"`
+	ImageURL    string `json:"image_url"`
+	ImageBase64 string `json:"image_base64"`
+}
+
+type voyageMultimodalInput struct {
+	Content []voyageContent `json:"content"`
+}
+
+// voyageMultimodalRequest is the Voyage multimodal embeddings API request schema.
+type voyageMultimodalRequest struct {
+	Inputs         []voyageMultimodalInput `json:"inputs"`
+	Model          string                  `json:"model"`
+	InputType      string                  `json:"input_type"`
+	Truncation     *bool                   `json:"truncation"`
+	OutputEncoding string                  `json:"output_encoding"`
+}
+
+// voyageContextualizedRequest is the Voyage contextualized chunk embeddings API request schema.
+type voyageContextualizedRequest struct {
+	Inputs          [][]string `json:"inputs"`
+	Model           string     `json:"model"`
+	InputType       string     `json:"input_type"`
+	OutputDimension int        `json:"output_dimension"`
+	OutputDType     string     `json:"output_dtype"`
+	OutputEncoding  string     `json:"output_encoding"`
+}
+
+type voyageData struct {
+	Object    string `json:"object"`
+	Index     int    `json:"index"`
+	Embedding any    `json:"embedding"`
+}
+
+type voyageUsage struct {
+	TextTokens  int `json:"text_tokens,omitempty"`
+	ImagePixels int `json:"image_pixels,omitempty"`
+	TotalTokens int `json:"total_tokens"`
+}
+
+type voyageResponse struct {
+	Object string       `json:"object"`
+	Data   []voyageData `json:"data"`
+	Model  string       `json:"model"`
+	Usage  voyageUsage  `json:"usage"`
+}
+
+type voyageContextualizedData struct {
+	Object string       `json:"object"`
+	Index  int          `json:"index"`
+	Data   []voyageData `json:"data"`
+}
+
+type voyageContextualizedResponse struct {
+	Object string                     `json:"object"`
+	Data   []voyageContextualizedData `json:"data"`
+	Model  string                     `json:"model"`
+	Usage  voyageUsage                `json:"usage"`
+}
+
+// NewVoyageServer starts the Voyage embeddings API emulator and returns it.
+// Use its URL as the voyage client base URL. It serves the text, multimodal
+// and contextualized chunk embeddings APIs in all the output data types
+// and encodings. Inputs longer than MaxInputLen are truncated unless
+// the request disables truncation.
+func NewVoyageServer(opts ...Option) *Server {
+	s := newServer(bearerAuth, voyageError, opts...)
+	s.handle("POST /v1/embeddings", s.voyageEmbeddings)
+	s.handle("POST /v1/multimodalembeddings", s.voyageMultimodalEmbeddings)
+	s.handle("POST /v1/contextualizedembeddings", s.voyageContextualizedEmbeddings)
+	return s.start()
+}
+
+// voyageEmbeddings handles the Voyage text embeddings request.
+func (s *Server) voyageEmbeddings(_ *http.Request, body []byte) (any, error) {
+	req := new(voyageRequest)
+	if err := decodeJSON(body, req); err != nil {
+		return nil, err
+	}
+	if err := voyageValidate(req.Model, req.InputType, req.EncodingFormat); err != nil {
+		return nil, err
+	}
+
+	if len(req.Input) == 0 {
+		return nil, badRequest("input is required")
+	}
+	inputs, err := decodeInputs(req.Input)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputs) == 0 || len(inputs) > VoyageMaxInputs {
+		return nil, badRequest("input must contain between 1 and %d inputs", VoyageMaxInputs)
+	}
+
+	resp := &voyageResponse{
+		Object: "list",
+		Data:   make([]voyageData, 0, len(inputs)),
+		Model:  req.Model,
+	}
+	for i, input := range inputs {
+		input, err := s.voyageTruncate(input, req.Truncation)
+		if err != nil {
+			return nil, err
+		}
+		emb, err := encodeVector(s.vector(input, req.OutputDimension), req.OutputDType, req.EncodingFormat == "base64")
+		if err != nil {
+			return nil, err
+		}
+		resp.Data = append(resp.Data, voyageData{
+			Object:    "embedding",
+			Index:     i,
+			Embedding: emb,
+		})
+		resp.Usage.TotalTokens += countTokens(input)
+	}
+
+	return resp, nil
+}
+
+// voyageMultimodalEmbeddings handles the Voyage multimodal embeddings request.
+// The inputs are embedded by their text content and image URLs or data.
+func (s *Server) voyageMultimodalEmbeddings(_ *http.Request, body []byte) (any, error) {
+	req := new(voyageMultimodalRequest)
+	if err := decodeJSON(body, req); err != nil {
+		return nil, err
+	}
+	if err := voyageValidate(req.Model, req.InputType, req.OutputEncoding); err != nil {
+		return nil, err
+	}
+	if len(req.Inputs) == 0 || len(req.Inputs) > VoyageMaxInputs {
+		return nil, badRequest("inputs must contain between 1 and %d inputs", VoyageMaxInputs)
+	}
+
+	resp := &voyageResponse{
+		Object: "list",
+		Data:   make([]voyageData, 0, len(req.Inputs)),
+		Model:  req.Model,
+	}
+	for i, input := range req.Inputs {
+		if len(input.Content) == 0 {
+			return nil, badRequest("input %d has no content", i)
+		}
+		var text strings.Builder
+		for _, c := range input.Content {
+			switch {
+			case c.Type == "text" && c.Text != "":
+				text.WriteString(c.Text)
+				resp.Usage.TextTokens += countTokens(c.Text)
+			case c.Type == "image_url" && c.ImageURL != "":
+				text.WriteString(c.ImageURL)
+				resp.Usage.ImagePixels += voyageImagePixels
+			case c.Type == "image_base64" && strings.HasPrefix(c.ImageBase64, "data:image/"):
+				text.WriteString(c.ImageBase64)
+				resp.Usage.ImagePixels += voyageImagePixels
+			default:
+				return nil, badRequest("input %d has invalid %q content", i, c.Type)
+			}
+		}
+		input, err := s.voyageTruncate(text.String(), req.Truncation)
+		if err != nil {
+			return nil, err
+		}
+		emb, err := encodeVector(s.vector(input, 0), "float", req.OutputEncoding == "base64")
+		if err != nil {
+			return nil, err
+		}
+		resp.Data = append(resp.Data, voyageData{
+			Object:    "embedding",
+			Index:     i,
+			Embedding: emb,
+		})
+	}
+	resp.Usage.TotalTokens = resp.Usage.TextTokens + resp.Usage.ImagePixels/voyagePixelsPerToken
+
+	return resp, nil
+}
+
+// voyageContextualizedEmbeddings handles the Voyage contextualized chunk embeddings request.
+// Every chunk is embedded together with its whole document.
+func (s *Server) voyageContextualizedEmbeddings(_ *http.Request, body []byte) (any, error) {
+	req := new(voyageContextualizedRequest)
+	if err := decodeJSON(body, req); err != nil {
+		return nil, err
+	}
+	if err := voyageValidate(req.Model, req.InputType, req.OutputEncoding); err != nil {
+		return nil, err
+	}
+	if len(req.Inputs) == 0 || len(req.Inputs) > VoyageMaxInputs {
+		return nil, badRequest("inputs must contain between 1 and %d documents", VoyageMaxInputs)
+	}
+
+	resp := &voyageContextualizedResponse{
+		Object: "list",
+		Data:   make([]voyageContextualizedData, 0, len(req.Inputs)),
+		Model:  req.Model,
+	}
+	for i, chunks := range req.Inputs {
+		if len(chunks) == 0 {
+			return nil, badRequest("document %d has no chunks", i)
+		}
+		doc := strings.Join(chunks, "")
+		if _, truncated := truncate(doc, s.opts.MaxInputLen); truncated {
+			return nil, badRequest("document %d exceeds the context length %d", i, s.opts.MaxInputLen)
+		}
+		data := voyageContextualizedData{
+			Object: "list",
+			Index:  i,
+			Data:   make([]voyageData, 0, len(chunks)),
+		}
+		for j, chunk := range chunks {
+			emb, err := encodeVector(s.vector(doc+"\x00"+chunk, req.OutputDimension), req.OutputDType, req.OutputEncoding == "base64")
+			if err != nil {
+				return nil, err
+			}
+			data.Data = append(data.Data, voyageData{
+				Object:    "embedding",
+				Index:     j,
+				Embedding: emb,
+			})
+		}
+		resp.Data = append(resp.Data, data)
+		resp.Usage.TotalTokens += countTokens(doc)
+	}
+
+	return resp, nil
+}
+
+// voyageTruncate truncates the input unless truncation is disabled.
+func (s *Server) voyageTruncate(input string, truncation *bool) (string, error) {
+	truncated, ok := truncate(input, s.opts.MaxInputLen)
+	if ok && truncation != nil && !*truncation {
+		return "", badRequest("input exceeds the context length %d", s.opts.MaxInputLen)
+	}
+	return truncated, nil
+}
+
+// voyageValidate validates the common Voyage request parameters.
+func voyageValidate(model, inputType, encoding string) error {
+	if model == "" {
+		return badRequest("model is required")
+	}
+	if !slices.Contains(voyageInputTypes, inputType) {
+		return badRequest("invalid input_type: %s", inputType)
+	}
+	switch encoding {
+	case "", "None", "base64":
+	default:
+		return badRequest("invalid encoding: %s", encoding)
+	}
+	return nil
+}
+
+// voyageError returns the Voyage API error.
+func voyageError(_ int, msg string) any {
+	return map[string]any{
+		"detail": msg,
+	}
+}
@@ -0,0 +1,85 @@
+package embeddingstest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings/voyage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoyageServer(t *testing.T) {
+	t.Parallel()
+
+	s := NewVoyageServer(WithAPIKey("key"))
+	defer s.Close()
+
+	ctx := context.Background()
+	c := voyage.NewClient(voyage.WithBaseURL(s.URL), voyage.WithAPIKey("key"))
+	want := Vector("foo", DefaultDims, 0, true)
+
+	for _, enc := range []voyage.EncodingFormat{"", voyage.EncodingNone, voyage.EncodingBase64} {
+		embs, err := c.Embed(ctx, &voyage.EmbeddingRequest{
+			Input:          []string{"foo"},
+			Model:          voyage.VoyageV2,
+			InputType:      voyage.QueryInput,
+			EncodingFormat: enc,
+		})
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, want, embs[0].Vector, 1e-6)
+	}
+
+	embs, err := c.MultimodalEmbed(ctx, &voyage.MultimodalEmbeddingRequest{
+		Inputs: []voyage.MultimodalInput{
+			voyage.NewMultimodalInput(voyage.NewTextContent("foo")),
+			voyage.NewMultimodalInput(voyage.NewImageURLContent("https://example.com/foo.png")),
+		},
+		Model:          voyage.MultimodalV3,
+		EncodingFormat: voyage.EncodingBase64,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 2)
+	assert.InDeltaSlice(t, want, embs[0].Vector, 1e-6)
+
+	docs, err := c.ContextualizedEmbed(ctx, &voyage.ContextualizedEmbeddingRequest{
+		Inputs: [][]string{{"foo", "bar"}, {"foo"}},
+		Model:  voyage.ContextV3,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+	assert.Len(t, docs[0], 2)
+	assert.NotEqual(t, docs[0][0].Vector, docs[1][0].Vector)
+
+	_, err = c.Embed(ctx, &voyage.EmbeddingRequest{Input: []string{"foo"}})
+	var apiErr voyage.APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.NotEmpty(t, apiErr.Detail)
+
+	header := http.Header{"Authorization": {"Bearer key"}}
+	resp, data := post(t, s.URL+"/v1/embeddings", header,
+		`{"input":"foo","model":"voyage-3","output_dtype":"int8","output_dimension":4}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	emb := data["data"].([]any)[0].(map[string]any)["embedding"]
+	assert.Len(t, emb, 4)
+
+	resp, data = post(t, s.URL+"/v1/embeddings", header,
+		`{"input":"foo","model":"voyage-3","output_dtype":"binary","encoding_format":"base64"}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.IsType(t, "", data["data"].([]any)[0].(map[string]any)["embedding"])
+}
+
+func TestVoyageServerTruncation(t *testing.T) {
+	t.Parallel()
+
+	s := NewVoyageServer(WithMaxInputLen(3, false))
+	defer s.Close()
+
+	header := http.Header{}
+	resp, _ := post(t, s.URL+"/v1/embeddings", header, `{"input":["foobar"],"model":"voyage-3"}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, data := post(t, s.URL+"/v1/embeddings", header, `{"input":["foobar"],"model":"voyage-3","truncation":false}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.NotEmpty(t, data["detail"])
+}
@@ -0,0 +1,52 @@
+package gemini
+
+import (
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings/client"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	geminiAPIKey = "somekey"
+)
+
+func TestClient(t *testing.T) {
+	t.Setenv("GEMINI_API_KEY", geminiAPIKey)
+
+	t.Run("API key", func(t *testing.T) {
+		c := NewClient()
+		assert.Equal(t, c.opts.APIKey, geminiAPIKey)
+
+		testVal := "foo"
+		c = NewClient(WithAPIKey(testVal))
+		assert.Equal(t, c.opts.APIKey, testVal)
+	})
+
+	t.Run("BaseURL", func(t *testing.T) {
+		c := NewClient()
+		assert.Equal(t, c.opts.BaseURL, BaseURL)
+
+		testVal := "http://foo"
+		c = NewClient(WithBaseURL(testVal))
+		assert.Equal(t, c.opts.BaseURL, testVal)
+	})
+
+	t.Run("version", func(t *testing.T) {
+		c := NewClient()
+		assert.Equal(t, c.opts.Version, EmbedAPIVersion)
+
+		testVal := "v1"
+		c = NewClient(WithVersion(testVal))
+		assert.Equal(t, c.opts.Version, testVal)
+	})
+
+	t.Run("http client", func(t *testing.T) {
+		c := NewClient()
+		assert.NotNil(t, c.opts.HTTPClient)
+
+		testVal := client.NewHTTP()
+		c = NewClient(WithHTTPClient(testVal))
+		assert.NotNil(t, c.opts.HTTPClient)
+	})
+}
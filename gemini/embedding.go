@@ -0,0 +1,185 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/milosgajdos/go-embeddings/request"
+)
+
+// EmbeddingRequest is used to fetch embeddings for every input.
+// It is sent to the embedContent API endpoint if it has a single
+// input and to the batchEmbedContents API endpoint otherwise.
+// https://ai.google.dev/api/embeddings
+type EmbeddingRequest struct {
+	Input    []string
+	Model    Model
+	TaskType TaskType
+	// NOTE: Title is only valid with TaskType set to RetrDocTask
+	Title                string
+	OutputDimensionality int
+}
+
+// Part is a content part.
+type Part struct {
+	Text string `json:"text"`
+}
+
+// Content is the content to embed.
+type Content struct {
+	Parts []Part `json:"parts"`
+}
+
+// ContentRequest is sent to the embedContent API endpoint.
+type ContentRequest struct {
+	// Model is the model resource name e.g. models/gemini-embedding-001
+	Model                string   `json:"model"`
+	Content              Content  `json:"content"`
+	TaskType             TaskType `json:"taskType,omitempty"`
+	Title                string   `json:"title,omitempty"`
+	OutputDimensionality int      `json:"outputDimensionality,omitempty"`
+}
+
+// BatchRequest is sent to the batchEmbedContents API endpoint.
+type BatchRequest struct {
+	Requests []ContentRequest `json:"requests"`
+}
+
+// ContentEmbedding is content embedding.
+type ContentEmbedding struct {
+	Values []float64 `json:"values"`
+}
+
+// ContentResponse is the embedContent API response.
+type ContentResponse struct {
+	Embedding ContentEmbedding `json:"embedding"`
+}
+
+// ToEmbeddings converts the API response,
+// into a slice of embeddings and returns it.
+func (e *ContentResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	floats := make([]float64, len(e.Embedding.Values))
+	copy(floats, e.Embedding.Values)
+	return []*embeddings.Embedding{
+		{Vector: floats},
+	}, nil
+}
+
+// BatchResponse is the batchEmbedContents API response.
+type BatchResponse struct {
+	Embeddings []ContentEmbedding `json:"embeddings"`
+}
+
+// ToEmbeddings converts the API response,
+// into a slice of embeddings and returns it.
+func (e *BatchResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	embs := make([]*embeddings.Embedding, 0, len(e.Embeddings))
+	for _, emb := range e.Embeddings {
+		floats := make([]float64, len(emb.Values))
+		copy(floats, emb.Values)
+		embs = append(embs, &embeddings.Embedding{
+			Vector: floats,
+		})
+	}
+	return embs, nil
+}
+
+// modelName returns the model resource name.
+func modelName(model Model) string {
+	return "models/" + model.String()
+}
+
+// toContentRequests converts the embedding request to content requests.
+func (e *EmbeddingRequest) toContentRequests() []ContentRequest {
+	reqs := make([]ContentRequest, 0, len(e.Input))
+	for _, input := range e.Input {
+		reqs = append(reqs, ContentRequest{
+			Model: modelName(e.Model),
+			Content: Content{
+				Parts: []Part{{Text: input}},
+			},
+			TaskType:             e.TaskType,
+			Title:                e.Title,
+			OutputDimensionality: e.OutputDimensionality,
+		})
+	}
+	return reqs
+}
+
+// Embed returns embeddings for every input in EmbeddingRequest.
+func (c *Client) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.Embedding, error) {
+	reqs := embReq.toContentRequests()
+
+	switch len(reqs) {
+	case 0:
+		return nil, ErrEmptyInput
+	case 1:
+		resp, err := c.EmbedContent(ctx, &reqs[0])
+		if err != nil {
+			return nil, err
+		}
+		return resp.ToEmbeddings()
+	}
+
+	resp, err := c.BatchEmbedContents(ctx, embReq.Model, &BatchRequest{Requests: reqs})
+	if err != nil {
+		return nil, err
+	}
+	return resp.ToEmbeddings()
+}
+
+// EmbedContent sends the content request to embedContent API endpoint.
+func (c *Client) EmbedContent(ctx context.Context, contentReq *ContentRequest) (*ContentResponse, error) {
+	resp := new(ContentResponse)
+	if err := c.post(ctx, contentReq.Model+":embedContent", contentReq, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// BatchEmbedContents sends the batch request to batchEmbedContents API endpoint.
+// NOTE: the model of every content request must match the given model.
+func (c *Client) BatchEmbedContents(ctx context.Context, model Model, batchReq *BatchRequest) (*BatchResponse, error) {
+	resp := new(BatchResponse)
+	if err := c.post(ctx, modelName(model)+":batchEmbedContents", batchReq, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// post sends the payload to the given API resource
+// and decodes the API response into resp.
+func (c *Client) post(ctx context.Context, resource string, payload, resp any) error {
+	u, err := url.Parse(c.opts.BaseURL + "/" + c.opts.Version + "/" + resource)
+	if err != nil {
+		return err
+	}
+
+	var body = &bytes.Buffer{}
+	enc := json.NewEncoder(body)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(payload); err != nil {
+		return err
+	}
+
+	options := []request.Option{
+		request.WithSetHeader(APIKeyHeader, c.opts.APIKey),
+	}
+
+	req, err := request.NewHTTP(ctx, http.MethodPost, u.String(), body, options...)
+	if err != nil {
+		return err
+	}
+
+	res, err := request.Do[APIError](c.opts.HTTPClient, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return json.NewDecoder(res.Body).Decode(resp)
+}
@@ -0,0 +1,75 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(APIKeyHeader) != geminiAPIKey {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"code":400,"message":"API key not valid","status":"INVALID_ARGUMENT"}}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1beta/models/gemini-embedding-001:embedContent":
+			req := new(ContentRequest)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			assert.Equal(t, req.Model, "models/gemini-embedding-001")
+			assert.Equal(t, req.Content.Parts, []Part{{Text: "foo"}})
+			assert.Equal(t, req.TaskType, RetrDocTask)
+			assert.Equal(t, req.Title, "title")
+			assert.Equal(t, req.OutputDimensionality, 768)
+			_, _ = w.Write([]byte(`{"embedding":{"values":[1.0]}}`))
+		case "/v1beta/models/gemini-embedding-001:batchEmbedContents":
+			req := new(BatchRequest)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			assert.Len(t, req.Requests, 2)
+			_, _ = w.Write([]byte(`{"embeddings":[{"values":[1.0]},{"values":[2.0]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewEmbedder(WithAPIKey(geminiAPIKey), WithBaseURL(srv.URL))
+
+	embs, err := c.Embed(ctx, &EmbeddingRequest{
+		Input:                []string{"foo"},
+		Model:                EmbeddingV1,
+		TaskType:             RetrDocTask,
+		Title:                "title",
+		OutputDimensionality: 768,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 1)
+	assert.Equal(t, embs[0].Vector, []float64{1.0})
+
+	embs, err = c.Embed(ctx, &EmbeddingRequest{
+		Input: []string{"foo", "bar"},
+		Model: EmbeddingV1,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 2)
+	assert.Equal(t, embs[1].Vector, []float64{2.0})
+
+	_, err = c.Embed(ctx, &EmbeddingRequest{Model: EmbeddingV1})
+	assert.ErrorIs(t, err, ErrEmptyInput)
+
+	c = NewEmbedder(WithAPIKey("foo"), WithBaseURL(srv.URL))
+	_, err = c.Embed(ctx, &EmbeddingRequest{
+		Input: []string{"foo"},
+		Model: EmbeddingV1,
+	})
+	assert.ErrorAs(t, err, &APIError{})
+}
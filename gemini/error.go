@@ -0,0 +1,29 @@
+package gemini
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrEmptyInput is returned when the embedding request has no input.
+	ErrEmptyInput = errors.New("empty input")
+)
+
+// APIError is Gemini API error.
+type APIError struct {
+	Err struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+// Error implements error interface.
+func (e APIError) Error() string {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "unknown error"
+	}
+	return string(b)
+}
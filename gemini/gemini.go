@@ -0,0 +1,38 @@
+package gemini
+
+// Model is embedding model.
+type Model string
+
+const (
+	EmbeddingV1   Model = "gemini-embedding-001"
+	EmbeddingExp  Model = "gemini-embedding-exp-03-07"
+	TextEmbedding Model = "text-embedding-004"
+)
+
+// String implements stringer.
+func (m Model) String() string {
+	return string(m)
+}
+
+// TaskType is embedding task type.
+// It can be used to improve the embedding quality
+// when targeting a specific task.
+// See: https://ai.google.dev/api/embeddings#tasktype
+type TaskType string
+
+const (
+	UnspecifiedTask    TaskType = "TASK_TYPE_UNSPECIFIED"
+	RetrQueryTask      TaskType = "RETRIEVAL_QUERY"
+	RetrDocTask        TaskType = "RETRIEVAL_DOCUMENT"
+	SemanticSimTask    TaskType = "SEMANTIC_SIMILARITY"
+	ClassificationTask TaskType = "CLASSIFICATION"
+	ClusteringTask     TaskType = "CLUSTERING"
+	QATask             TaskType = "QUESTION_ANSWERING"
+	FactVerifTask      TaskType = "FACT_VERIFICATION"
+	CodeRetrQueryTask  TaskType = "CODE_RETRIEVAL_QUERY"
+)
+
+// String implements stringer.
+func (t TaskType) String() string {
+	return string(t)
+}
@@ -0,0 +1,95 @@
+package hf
+
+import (
+	"os"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/milosgajdos/go-embeddings/client"
+)
+
+const (
+	// BaseURL is Hugging Face Inference API base URL.
+	BaseURL = "https://api-inference.huggingface.co"
+)
+
+// Client is Hugging Face Inference API client.
+type Client struct {
+	opts Options
+}
+
+// Options are client options
+type Options struct {
+	Token   string
+	BaseURL string
+	// EndpointURL is the URL of a dedicated Inference Endpoint.
+	// If set, the requests are sent to it and their Model is ignored.
+	EndpointURL string
+	HTTPClient  *client.HTTP
+	// MeanPool makes Embed mean-pool the token
+	// embeddings returned by models without pooling.
+	MeanPool bool
+}
+
+// Option is functional option.
+type Option func(*Options)
+
+// NewClient creates a new HTTP API client and returns it.
+// By default it reads the Hugging Face token from HF_TOKEN
+// env var and uses the default Go http.Client for making API requests.
+// You can override the default options via the client methods.
+func NewClient(opts ...Option) *Client {
+	options := Options{
+		Token:      os.Getenv("HF_TOKEN"),
+		BaseURL:    BaseURL,
+		HTTPClient: client.NewHTTP(),
+	}
+
+	for _, apply := range opts {
+		apply(&options)
+	}
+
+	return &Client{
+		opts: options,
+	}
+}
+
+// NewEmbedder creates a client that implements embeddings.Embedder
+func NewEmbedder(opts ...Option) embeddings.Embedder[*EmbeddingRequest] {
+	return NewClient(opts...)
+}
+
+// WithToken sets the API token.
+func WithToken(token string) Option {
+	return func(o *Options) {
+		o.Token = token
+	}
+}
+
+// WithBaseURL sets the API base URL.
+func WithBaseURL(baseURL string) Option {
+	return func(o *Options) {
+		o.BaseURL = baseURL
+	}
+}
+
+// WithEndpointURL sets the Inference Endpoint URL.
+func WithEndpointURL(endpointURL string) Option {
+	return func(o *Options) {
+		o.EndpointURL = endpointURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client.
+func WithHTTPClient(httpClient *client.HTTP) Option {
+	return func(o *Options) {
+		o.HTTPClient = httpClient
+	}
+}
+
+// WithMeanPool enables client-side mean pooling
+// of the token embeddings returned by Embed.
+func WithMeanPool(meanPool bool) Option {
+	return func(o *Options) {
+		o.MeanPool = meanPool
+	}
+}
@@ -0,0 +1,60 @@
+package hf
+
+import (
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings/client"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	hfToken = "sometoken"
+)
+
+func TestClient(t *testing.T) {
+	t.Setenv("HF_TOKEN", hfToken)
+
+	t.Run("token", func(t *testing.T) {
+		c := NewClient()
+		assert.Equal(t, c.opts.Token, hfToken)
+
+		testVal := "foo"
+		c = NewClient(WithToken(testVal))
+		assert.Equal(t, c.opts.Token, testVal)
+	})
+
+	t.Run("BaseURL", func(t *testing.T) {
+		c := NewClient()
+		assert.Equal(t, c.opts.BaseURL, BaseURL)
+
+		testVal := "http://foo"
+		c = NewClient(WithBaseURL(testVal))
+		assert.Equal(t, c.opts.BaseURL, testVal)
+	})
+
+	t.Run("EndpointURL", func(t *testing.T) {
+		c := NewClient()
+		assert.Equal(t, c.opts.EndpointURL, "")
+
+		testVal := "http://foo.endpoints.huggingface.cloud"
+		c = NewClient(WithEndpointURL(testVal))
+		assert.Equal(t, c.opts.EndpointURL, testVal)
+	})
+
+	t.Run("http client", func(t *testing.T) {
+		c := NewClient()
+		assert.NotNil(t, c.opts.HTTPClient)
+
+		testVal := client.NewHTTP()
+		c = NewClient(WithHTTPClient(testVal))
+		assert.NotNil(t, c.opts.HTTPClient)
+	})
+
+	t.Run("mean pool", func(t *testing.T) {
+		c := NewClient()
+		assert.False(t, c.opts.MeanPool)
+
+		c = NewClient(WithMeanPool(true))
+		assert.True(t, c.opts.MeanPool)
+	})
+}
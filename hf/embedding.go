@@ -0,0 +1,241 @@
+package hf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/milosgajdos/go-embeddings/request"
+)
+
+// Parameters are feature extraction parameters.
+// NOTE: they're only supported by models served
+// with Text Embeddings Inference.
+type Parameters struct {
+	Normalize           *bool  `json:"normalize,omitempty"`
+	Truncate            *bool  `json:"truncate,omitempty"`
+	TruncationDirection string `json:"truncation_direction,omitempty"`
+	PromptName          string `json:"prompt_name,omitempty"`
+}
+
+// RequestOptions are inference options.
+type RequestOptions struct {
+	// WaitForModel makes the API wait for the model
+	// to load instead of returning the model loading error.
+	WaitForModel bool `json:"wait_for_model,omitempty"`
+	// UseCache makes the API return cached results.
+	// It defaults to true on the server.
+	UseCache *bool `json:"use_cache,omitempty"`
+}
+
+// EmbeddingRequest is sent to the feature extraction API.
+// https://huggingface.co/docs/api-inference/tasks/feature-extraction
+type EmbeddingRequest struct {
+	// Inputs is either a string or a slice of strings.
+	Inputs any `json:"inputs"`
+	// Model is the Hugging Face Hub model ID
+	// e.g. sentence-transformers/all-MiniLM-L6-v2.
+	Model      string          `json:"-"`
+	Parameters *Parameters     `json:"parameters,omitempty"`
+	Options    *RequestOptions `json:"options,omitempty"`
+}
+
+// EmbeddingResponse contains the embeddings of every input.
+// Every input has a single pooled embedding or the embedding
+// of every input token if the model does not pool them.
+type EmbeddingResponse [][][]float64
+
+// ToEmbeddings converts the API response,
+// into a slice of embeddings and returns it.
+// It returns embeddings.ErrUnpooled if the
+// response contains unpooled token embeddings.
+func (e EmbeddingResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	mvs, err := e.ToMultiVectors()
+	if err != nil {
+		return nil, err
+	}
+	return embeddings.PooledEmbeddings(mvs)
+}
+
+// ToTokenEmbeddings converts the API response,
+// into a slice of token embeddings for every input.
+func (e EmbeddingResponse) ToTokenEmbeddings() ([][]*embeddings.Embedding, error) {
+	mvs, err := e.ToMultiVectors()
+	if err != nil {
+		return nil, err
+	}
+	return embeddings.TokenEmbeddings(mvs), nil
+}
+
+// ToMultiVectors converts the API response,
+// into a multi-vector embedding for every input.
+func (e EmbeddingResponse) ToMultiVectors() ([]*embeddings.MultiVector, error) {
+	return embeddings.NewMultiVectors(e), nil
+}
+
+// MeanPool mean-pools the embeddings of every input and returns them.
+// Pooled embeddings are returned unchanged.
+// NOTE: the mean-pooled embeddings are not normalized.
+func (e EmbeddingResponse) MeanPool() ([]*embeddings.Embedding, error) {
+	mvs, err := e.ToMultiVectors()
+	if err != nil {
+		return nil, err
+	}
+	return embeddings.MeanPoolEmbeddings(mvs)
+}
+
+// Embed returns embeddings for every object in EmbeddingRequest.
+// The token embeddings are mean-pooled if the client MeanPool
+// option is enabled, otherwise embeddings.ErrUnpooled is returned for them.
+func (c *Client) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.Embedding, error) {
+	embs, err := c.Embeddings(ctx, embReq)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.MeanPool {
+		return embs.MeanPool()
+	}
+	return embs.ToEmbeddings()
+}
+
+// Embeddings fetches the features of every input in EmbeddingRequest
+// and normalizes their shape into the embeddings of every input.
+// If the model is loading the returned error matches ErrModelLoading
+// and it's an APIError which reports the estimated loading time.
+func (c *Client) Embeddings(ctx context.Context, embReq *EmbeddingRequest) (EmbeddingResponse, error) {
+	var single bool
+	switch embReq.Inputs.(type) {
+	case string:
+		single = true
+	case []string:
+	default:
+		return nil, ErrInvalidInput
+	}
+
+	endpoint := c.opts.EndpointURL
+	if endpoint == "" {
+		endpoint = c.opts.BaseURL + "/pipeline/feature-extraction/" + embReq.Model
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var body = &bytes.Buffer{}
+	enc := json.NewEncoder(body)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(embReq); err != nil {
+		return nil, err
+	}
+
+	var options []request.Option
+	if c.opts.Token != "" {
+		options = append(options, request.WithBearer(c.opts.Token))
+	}
+
+	req, err := request.NewHTTP(ctx, http.MethodPost, u.String(), body, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := request.Do[APIError](c.opts.HTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var features any
+	if err := json.NewDecoder(resp.Body).Decode(&features); err != nil {
+		return nil, err
+	}
+
+	return toEmbeddingResp(features, single)
+}
+
+// toEmbeddingResp normalizes the shape of the returned features.
+// The depth of the features depends on the number of inputs and
+// the model pooling: single input features are either a sentence
+// embedding or token embeddings, batch features are either sentence
+// embeddings or token embeddings of every input.
+func toEmbeddingResp(features any, single bool) (EmbeddingResponse, error) {
+	if single {
+		features = []any{features}
+	}
+
+	batch, ok := features.([]any)
+	if !ok {
+		return nil, ErrInValidData
+	}
+
+	resp := make(EmbeddingResponse, 0, len(batch))
+	for _, input := range batch {
+		tokens, err := toTokens(input)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, tokens)
+	}
+	return resp, nil
+}
+
+// toTokens converts the input features into token embeddings.
+// Sentence embeddings are returned as a single token embedding.
+// NOTE: some models return token embeddings nested in a singleton batch.
+func toTokens(input any) ([][]float64, error) {
+	switch depth(input) {
+	case 1:
+		vals, err := toFloats(input)
+		if err != nil {
+			return nil, err
+		}
+		return [][]float64{vals}, nil
+	case 2:
+		rows := input.([]any)
+		tokens := make([][]float64, 0, len(rows))
+		for _, row := range rows {
+			vals, err := toFloats(row)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, vals)
+		}
+		return tokens, nil
+	case 3:
+		if rows := input.([]any); len(rows) == 1 {
+			return toTokens(rows[0])
+		}
+	}
+	return nil, ErrInValidData
+}
+
+// depth returns the depth of nested arrays.
+func depth(v any) int {
+	arr, ok := v.([]any)
+	if !ok {
+		return 0
+	}
+	if len(arr) == 0 {
+		return 1
+	}
+	return 1 + depth(arr[0])
+}
+
+// toFloats converts the array of numbers into floats.
+func toFloats(v any) ([]float64, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, ErrInValidData
+	}
+	floats := make([]float64, 0, len(arr))
+	for _, x := range arr {
+		f, ok := x.(float64)
+		if !ok {
+			return nil, ErrInValidData
+		}
+		floats = append(floats, f)
+	}
+	return floats, nil
+}
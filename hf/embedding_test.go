@@ -0,0 +1,175 @@
+package hf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToEmbeddingResp(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		data   string
+		single bool
+		exp    EmbeddingResponse
+		err    error
+	}{
+		{name: "single pooled", data: `[1,2]`, single: true, exp: EmbeddingResponse{{{1, 2}}}},
+		{name: "single tokens", data: `[[1,2],[3,4]]`, single: true, exp: EmbeddingResponse{{{1, 2}, {3, 4}}}},
+		{name: "single nested tokens", data: `[[[1,2],[3,4]]]`, single: true, exp: EmbeddingResponse{{{1, 2}, {3, 4}}}},
+		{name: "batch pooled", data: `[[1,2],[3,4]]`, exp: EmbeddingResponse{{{1, 2}}, {{3, 4}}}},
+		{name: "batch tokens", data: `[[[1,2],[3,4]],[[5,6]]]`, exp: EmbeddingResponse{{{1, 2}, {3, 4}}, {{5, 6}}}},
+		{name: "batch nested tokens", data: `[[[[1,2],[3,4]]],[[[5,6]]]]`, exp: EmbeddingResponse{{{1, 2}, {3, 4}}, {{5, 6}}}},
+		{name: "not array", data: `{"foo":"bar"}`, err: ErrInValidData},
+		{name: "not numbers", data: `[["foo"]]`, err: ErrInValidData},
+		{name: "too deep", data: `[[[[1]],[[2]]]]`, single: true, err: ErrInValidData},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var features any
+			assert.NoError(t, json.Unmarshal([]byte(tc.data), &features))
+			resp, err := toEmbeddingResp(features, tc.single)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.exp, resp)
+		})
+	}
+}
+
+func TestPooling(t *testing.T) {
+	t.Parallel()
+
+	resp := EmbeddingResponse{{{1, 2}, {3, 4}, {5, 6}}, {{1, 1}}}
+
+	_, err := resp.ToEmbeddings()
+	assert.ErrorIs(t, err, embeddings.ErrUnpooled)
+
+	tokens, err := resp.ToTokenEmbeddings()
+	assert.NoError(t, err)
+	assert.Len(t, tokens[0], 3)
+
+	mvs, err := resp.ToMultiVectors()
+	assert.NoError(t, err)
+	assert.Len(t, mvs, 2)
+	assert.Equal(t, mvs[0].Len(), 3)
+	assert.Equal(t, mvs[1].Vectors, [][]float64{{1, 1}})
+
+	embs, err := resp.MeanPool()
+	assert.NoError(t, err)
+	assert.Equal(t, embs[0].Vector, []float64{3, 4})
+	assert.Equal(t, embs[1].Vector, []float64{1, 1})
+}
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+hfToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"Invalid credentials in Authorization header"}`))
+			return
+		}
+
+		req := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		switch r.URL.Path {
+		case "/pipeline/feature-extraction/sentence-transformers/all-MiniLM-L6-v2":
+			assert.Equal(t, req["inputs"], []any{"foo", "bar"})
+			assert.Equal(t, req["options"], map[string]any{"wait_for_model": true, "use_cache": false})
+			_, _ = w.Write([]byte(`[[1.0,2.0],[3.0,4.0]]`))
+		case "/pipeline/feature-extraction/bert-base-uncased":
+			_, _ = w.Write([]byte(`[[[1.0,2.0],[3.0,4.0]]]`))
+		case "/pipeline/feature-extraction/loading":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":"Model loading is currently loading","estimated_time":20.5}`))
+		case "/endpoint":
+			assert.Equal(t, req["parameters"], map[string]any{"truncate": true})
+			_, _ = w.Write([]byte(`[1.0,2.0]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	t.Run("pooled", func(t *testing.T) {
+		useCache := false
+		c := NewEmbedder(WithToken(hfToken), WithBaseURL(srv.URL))
+		embs, err := c.Embed(ctx, &EmbeddingRequest{
+			Inputs: []string{"foo", "bar"},
+			Model:  "sentence-transformers/all-MiniLM-L6-v2",
+			Options: &RequestOptions{
+				WaitForModel: true,
+				UseCache:     &useCache,
+			},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, embs, 2)
+		assert.Equal(t, embs[1].Vector, []float64{3.0, 4.0})
+	})
+
+	t.Run("mean pool", func(t *testing.T) {
+		embReq := &EmbeddingRequest{Inputs: "foo", Model: "bert-base-uncased"}
+
+		c := NewEmbedder(WithToken(hfToken), WithBaseURL(srv.URL))
+		_, err := c.Embed(ctx, embReq)
+		assert.ErrorIs(t, err, embeddings.ErrUnpooled)
+
+		c = NewEmbedder(WithToken(hfToken), WithBaseURL(srv.URL), WithMeanPool(true))
+		embs, err := c.Embed(ctx, embReq)
+		assert.NoError(t, err)
+		assert.Equal(t, embs[0].Vector, []float64{2.0, 3.0})
+	})
+
+	t.Run("endpoint", func(t *testing.T) {
+		truncate := true
+		c := NewClient(WithToken(hfToken), WithEndpointURL(srv.URL+"/endpoint"))
+		embs, err := c.Embed(ctx, &EmbeddingRequest{
+			Inputs:     "foo",
+			Model:      "ignored",
+			Parameters: &Parameters{Truncate: &truncate},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, embs[0].Vector, []float64{1.0, 2.0})
+	})
+
+	t.Run("model loading", func(t *testing.T) {
+		c := NewClient(WithToken(hfToken), WithBaseURL(srv.URL))
+		_, err := c.Embed(ctx, &EmbeddingRequest{Inputs: "foo", Model: "loading"})
+		assert.ErrorIs(t, err, ErrModelLoading)
+		var apiErr APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, apiErr.RetryAfter(), 20500*time.Millisecond)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		c := NewClient(WithToken("foo"), WithBaseURL(srv.URL))
+		_, err := c.Embed(ctx, &EmbeddingRequest{Inputs: "foo", Model: "bert-base-uncased"})
+		assert.NotErrorIs(t, err, ErrModelLoading)
+		var apiErr APIError
+		assert.ErrorAs(t, err, &apiErr)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		c := NewClient(WithToken(hfToken), WithBaseURL(srv.URL))
+		_, err := c.Embed(ctx, &EmbeddingRequest{Inputs: 1, Model: "bert-base-uncased"})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
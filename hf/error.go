@@ -0,0 +1,46 @@
+package hf
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInValidData is returned when the API client fails to decode the returned data.
+	ErrInValidData = errors.New("invalid data")
+	// ErrInvalidInput is returned when the request input is neither a string nor a slice of strings.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrModelLoading is returned when the model is not loaded yet.
+	ErrModelLoading = errors.New("model loading")
+)
+
+// APIError is Hugging Face Inference API error.
+type APIError struct {
+	ErrorMessage string `json:"error"`
+	// EstimatedTime is the estimated number of
+	// seconds until the model is loaded.
+	EstimatedTime float64  `json:"estimated_time,omitempty"`
+	Warnings      []string `json:"warnings,omitempty"`
+}
+
+// Error implements error interface.
+func (e APIError) Error() string {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "unknown error"
+	}
+	return string(b)
+}
+
+// Is makes errors.Is report the model loading
+// errors returned by the API as ErrModelLoading.
+func (e APIError) Is(target error) bool {
+	return target == ErrModelLoading && e.EstimatedTime > 0
+}
+
+// RetryAfter returns the estimated time
+// until the model is loaded.
+func (e APIError) RetryAfter() time.Duration {
+	return time.Duration(e.EstimatedTime * float64(time.Second))
+}
@@ -0,0 +1,238 @@
+package jina
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/milosgajdos/go-embeddings/request"
+)
+
+// Input is an embedding input.
+// It's either a text or an image.
+type Input struct {
+	Text string `json:"text,omitempty"`
+	// Image is either an image URL or
+	// a base64 encoded image.
+	// NOTE: only supported by CLIP models.
+	Image string `json:"image,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// Text inputs are encoded as plain strings
+// which are accepted by all the models.
+func (i Input) MarshalJSON() ([]byte, error) {
+	if i.Image == "" {
+		return json.Marshal(i.Text)
+	}
+	type input Input
+	return json.Marshal(input(i))
+}
+
+// NewTextInput creates a text input.
+func NewTextInput(text string) Input {
+	return Input{Text: text}
+}
+
+// NewImageURLInput creates an image input from the image URL.
+func NewImageURLInput(url string) Input {
+	return Input{Image: url}
+}
+
+// NewImageInput creates an image input from the raw image data.
+func NewImageInput(data []byte) Input {
+	return Input{Image: base64.StdEncoding.EncodeToString(data)}
+}
+
+// TextInputs creates text inputs from texts.
+func TextInputs(texts ...string) []Input {
+	inputs := make([]Input, 0, len(texts))
+	for _, text := range texts {
+		inputs = append(inputs, NewTextInput(text))
+	}
+	return inputs
+}
+
+// EmbeddingRequest sent to API endpoint.
+// https://api.jina.ai/redoc#tag/embeddings
+type EmbeddingRequest struct {
+	Input []Input `json:"input"`
+	Model Model   `json:"model"`
+	// NOTE: the following parameters are only supported by EmbeddingsV3.
+	Task Task `json:"task,omitempty"`
+	// Dimensions truncates the output embeddings.
+	Dimensions int `json:"dimensions,omitempty"`
+	// LateChunking embeds the inputs as chunks of a single
+	// document so the chunk embeddings keep the document context.
+	LateChunking  bool          `json:"late_chunking,omitempty"`
+	EmbeddingType EmbeddingType `json:"embedding_type,omitempty"`
+	// Normalized scales the embeddings to unit length.
+	// The API default is used if Normalized is nil.
+	Normalized *bool `json:"normalized,omitempty"`
+	// Truncate truncates the inputs exceeding the model context length.
+	// The API default is used if Truncate is nil.
+	Truncate *bool `json:"truncate,omitempty"`
+}
+
+// Usage tracks API token usage.
+type Usage struct {
+	PromptTokens int `json:"prompt_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
+
+// Data stores vector embeddings.
+type Data struct {
+	Object    string    `json:"object"`
+	Index     int       `json:"index"`
+	Embedding []float64 `json:"embedding"`
+}
+
+// EmbeddingResponse received from API.
+type EmbeddingResponse struct {
+	Object string `json:"object"`
+	Data   []Data `json:"data"`
+	Model  Model  `json:"model"`
+	Usage  Usage  `json:"usage"`
+	// EmbeddingType of the requested embeddings.
+	EmbeddingType EmbeddingType `json:"-"`
+}
+
+// ToEmbeddings converts the API response,
+// into a slice of embeddings and returns it.
+func (e *EmbeddingResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	embs := make([]*embeddings.Embedding, 0, len(e.Data))
+	for _, d := range e.Data {
+		floats := make([]float64, len(d.Embedding))
+		copy(floats, d.Embedding)
+		emb := &embeddings.Embedding{
+			Vector: floats,
+		}
+		embs = append(embs, emb)
+	}
+	return embs, nil
+}
+
+// ToBinaryEmbeddings converts the bit-packed binary
+// or ubinary embeddings into binary embeddings.
+// It returns ErrNotBinary for any other embedding type.
+func (e *EmbeddingResponse) ToBinaryEmbeddings() ([]*embeddings.BinaryEmbedding, error) {
+	if e.EmbeddingType != BinaryEmbedding && e.EmbeddingType != UbinaryEmbedding {
+		return nil, ErrNotBinary
+	}
+	embs := make([]*embeddings.BinaryEmbedding, 0, len(e.Data))
+	for _, d := range e.Data {
+		packed := make([]byte, len(d.Embedding))
+		for i, v := range d.Embedding {
+			// NOTE: binary embeddings are int8 values
+			// of the packed bytes, i.e. two's complement.
+			packed[i] = byte(int(v)) // nolint:gosec
+		}
+		embs = append(embs, &embeddings.BinaryEmbedding{
+			Vector: packed,
+			Dims:   len(packed) * 8,
+		})
+	}
+	return embs, nil
+}
+
+// rawData stores either float or base64 encoded embeddings.
+type rawData struct {
+	Object    string          `json:"object"`
+	Index     int             `json:"index"`
+	Embedding json.RawMessage `json:"embedding"`
+}
+
+// rawResponse is the raw API response.
+type rawResponse struct {
+	Object string    `json:"object"`
+	Data   []rawData `json:"data"`
+	Model  Model     `json:"model"`
+	Usage  Usage     `json:"usage"`
+}
+
+// toEmbeddingResp decodes the raw API response.
+func toEmbeddingResp(raw *rawResponse, embType EmbeddingType) (*EmbeddingResponse, error) {
+	data := make([]Data, 0, len(raw.Data))
+	for _, d := range raw.Data {
+		var vals []float64
+		if embType == Base64Embedding {
+			var s string
+			if err := json.Unmarshal(d.Embedding, &s); err != nil {
+				return nil, ErrInValidData
+			}
+			emb, err := embeddings.Base64(s).DecodeFloat32()
+			if err != nil {
+				return nil, err
+			}
+			vals = emb.Vector
+		} else if err := json.Unmarshal(d.Embedding, &vals); err != nil {
+			return nil, ErrInValidData
+		}
+		data = append(data, Data{
+			Object:    d.Object,
+			Index:     d.Index,
+			Embedding: vals,
+		})
+	}
+	sort.SliceStable(data, func(i, j int) bool { return data[i].Index < data[j].Index })
+
+	return &EmbeddingResponse{
+		Object:        raw.Object,
+		Data:          data,
+		Model:         raw.Model,
+		Usage:         raw.Usage,
+		EmbeddingType: embType,
+	}, nil
+}
+
+// Embed returns embeddings for every object in EmbeddingRequest.
+func (c *Client) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.Embedding, error) {
+	embs, err := c.Embeddings(ctx, embReq)
+	if err != nil {
+		return nil, err
+	}
+	return embs.ToEmbeddings()
+}
+
+// Embeddings fetches embeddings for every object in EmbeddingRequest
+// and returns the API response including the token usage.
+func (c *Client) Embeddings(ctx context.Context, embReq *EmbeddingRequest) (*EmbeddingResponse, error) {
+	u, err := url.Parse(c.opts.BaseURL + "/" + c.opts.Version + "/embeddings")
+	if err != nil {
+		return nil, err
+	}
+
+	var body = &bytes.Buffer{}
+	enc := json.NewEncoder(body)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(embReq); err != nil {
+		return nil, err
+	}
+
+	options := []request.Option{
+		request.WithBearer(c.opts.APIKey),
+	}
+
+	req, err := request.NewHTTP(ctx, http.MethodPost, u.String(), body, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := request.Do[APIError](c.opts.HTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw := new(rawResponse)
+	if err := json.NewDecoder(resp.Body).Decode(raw); err != nil {
+		return nil, err
+	}
+
+	return toEmbeddingResp(raw, embReq.EmbeddingType)
+}
@@ -0,0 +1,132 @@
+package jina
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInput(t *testing.T) {
+	t.Parallel()
+
+	inputs := []Input{
+		NewTextInput("foo"),
+		NewImageURLInput("https://foo.com/bar.png"),
+		NewImageInput([]byte("bar")),
+	}
+	b, err := json.Marshal(inputs)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `["foo",{"image":"https://foo.com/bar.png"},{"image":"YmFy"}]`, string(b))
+}
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1/embeddings")
+		if r.Header.Get("Authorization") != "Bearer "+jinaAPIKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"detail":"Unauthorized"}`))
+			return
+		}
+
+		req := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req["embedding_type"] {
+		case nil:
+			assert.Equal(t, req["input"], []any{"foo", "bar"})
+			assert.Equal(t, req["task"], RetrPassageTask.String())
+			assert.Equal(t, req["dimensions"], float64(256))
+			assert.Equal(t, req["normalized"], false)
+			assert.NotContains(t, req, "truncate")
+			_, _ = w.Write([]byte(`{"object":"list","model":"jina-embeddings-v3","usage":{"total_tokens":2,"prompt_tokens":2},"data":[{"object":"embedding","index":1,"embedding":[2.0]},{"object":"embedding","index":0,"embedding":[1.0]}]}`))
+		case Base64Embedding.String():
+			_, _ = w.Write([]byte(`{"object":"list","model":"jina-embeddings-v3","usage":{"total_tokens":1,"prompt_tokens":1},"data":[{"object":"embedding","index":0,"embedding":"AACAPwAAAEA="}]}`))
+		case BinaryEmbedding.String():
+			_, _ = w.Write([]byte(`{"object":"list","model":"jina-embeddings-v3","usage":{"total_tokens":1,"prompt_tokens":1},"data":[{"object":"embedding","index":0,"embedding":[-104,64]}]}`))
+		case UbinaryEmbedding.String():
+			_, _ = w.Write([]byte(`{"object":"list","model":"jina-embeddings-v3","usage":{"total_tokens":1,"prompt_tokens":1},"data":[{"object":"embedding","index":0,"embedding":[152,64]}]}`))
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"detail":[{"loc":["body","embedding_type"],"msg":"invalid embedding type","type":"value_error"}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	t.Run("float", func(t *testing.T) {
+		c := NewEmbedder(WithAPIKey(jinaAPIKey), WithBaseURL(srv.URL))
+		normalized := false
+		embs, err := c.Embed(ctx, &EmbeddingRequest{
+			Input:      TextInputs("foo", "bar"),
+			Model:      EmbeddingsV3,
+			Task:       RetrPassageTask,
+			Dimensions: 256,
+			Normalized: &normalized,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, embs, 2)
+		assert.Equal(t, embs[0].Vector, []float64{1.0})
+		assert.Equal(t, embs[1].Vector, []float64{2.0})
+	})
+
+	t.Run("base64", func(t *testing.T) {
+		c := NewClient(WithAPIKey(jinaAPIKey), WithBaseURL(srv.URL))
+		resp, err := c.Embeddings(ctx, &EmbeddingRequest{
+			Input:         TextInputs("foo"),
+			Model:         EmbeddingsV3,
+			EmbeddingType: Base64Embedding,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, resp.Usage.TotalTokens, 1)
+		assert.Equal(t, resp.Data[0].Embedding, []float64{1.0, 2.0})
+		_, err = resp.ToBinaryEmbeddings()
+		assert.ErrorIs(t, err, ErrNotBinary)
+	})
+
+	for _, embType := range []EmbeddingType{BinaryEmbedding, UbinaryEmbedding} {
+		embType := embType
+		t.Run(embType.String(), func(t *testing.T) {
+			c := NewClient(WithAPIKey(jinaAPIKey), WithBaseURL(srv.URL))
+			resp, err := c.Embeddings(ctx, &EmbeddingRequest{
+				Input:         TextInputs("foo"),
+				Model:         EmbeddingsV3,
+				EmbeddingType: embType,
+			})
+			assert.NoError(t, err)
+			embs, err := resp.ToBinaryEmbeddings()
+			assert.NoError(t, err)
+			assert.Len(t, embs, 1)
+			assert.Equal(t, embs[0].Vector, []byte{0x98, 0x40})
+			assert.Equal(t, embs[0].Dims, 16)
+		})
+	}
+
+	t.Run("API error", func(t *testing.T) {
+		c := NewClient(WithAPIKey(jinaAPIKey), WithBaseURL(srv.URL))
+		_, err := c.Embed(ctx, &EmbeddingRequest{
+			Input:         TextInputs("foo"),
+			Model:         EmbeddingsV3,
+			EmbeddingType: "foo",
+		})
+		var apiErr APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.NotNil(t, apiErr.Detail)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		c := NewClient(WithAPIKey("bar"), WithBaseURL(srv.URL))
+		_, err := c.Embed(ctx, &EmbeddingRequest{
+			Input: TextInputs("foo"),
+			Model: EmbeddingsV3,
+		})
+		var apiErr APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, apiErr.Detail, "Unauthorized")
+	})
+}
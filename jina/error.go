@@ -0,0 +1,29 @@
+package jina
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrInValidData is returned when the API client fails to decode the returned data.
+	ErrInValidData = errors.New("invalid data")
+	// ErrNotBinary is returned when converting non-binary embeddings to binary embeddings.
+	ErrNotBinary = errors.New("not binary embeddings")
+)
+
+// APIError is Jina API error.
+type APIError struct {
+	// Detail is either a string or
+	// a detailed request validation error.
+	Detail any `json:"detail"`
+}
+
+// Error implements error interface.
+func (e APIError) Error() string {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "unknown error"
+	}
+	return string(b)
+}
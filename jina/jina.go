@@ -0,0 +1,55 @@
+package jina
+
+// Model is an embedding model.
+type Model string
+
+const (
+	EmbeddingsV3       Model = "jina-embeddings-v3"
+	EmbeddingsV2BaseEN Model = "jina-embeddings-v2-base-en"
+	EmbeddingsV2Code   Model = "jina-embeddings-v2-base-code"
+	// CLIP* are multimodal embedding models.
+	// They can embed both text and image inputs.
+	CLIPV1 Model = "jina-clip-v1"
+	CLIPV2 Model = "jina-clip-v2"
+)
+
+// String implements stringer.
+func (m Model) String() string {
+	return string(m)
+}
+
+// Task is a downstream task the embeddings are optimized for.
+// NOTE: only supported by EmbeddingsV3.
+type Task string
+
+const (
+	RetrQueryTask      Task = "retrieval.query"
+	RetrPassageTask    Task = "retrieval.passage"
+	SeparationTask     Task = "separation"
+	ClassificationTask Task = "classification"
+	TextMatchingTask   Task = "text-matching"
+)
+
+// String implements stringer.
+func (t Task) String() string {
+	return string(t)
+}
+
+// EmbeddingType is the type of the returned embeddings.
+type EmbeddingType string
+
+const (
+	FloatEmbedding EmbeddingType = "float"
+	// Base64Embedding makes Jina API return embeddings
+	// encoded as base64 string
+	Base64Embedding EmbeddingType = "base64"
+	// BinaryEmbedding and UbinaryEmbedding are bit-packed
+	// embeddings returned as int8 and uint8 values.
+	BinaryEmbedding  EmbeddingType = "binary"
+	UbinaryEmbedding EmbeddingType = "ubinary"
+)
+
+// String implements stringer.
+func (e EmbeddingType) String() string {
+	return string(e)
+}
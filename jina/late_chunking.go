@@ -0,0 +1,48 @@
+package jina
+
+import (
+	"context"
+
+	"github.com/milosgajdos/go-embeddings"
+)
+
+// ChunkEmbedding is an embedding of a document chunk.
+type ChunkEmbedding struct {
+	*embeddings.Embedding
+	// Chunk is the embedded chunk.
+	Chunk string
+}
+
+// EmbedChunks splits the document into chunks using the given splitter
+// and embeds the chunks with late chunking so every chunk embedding keeps
+// the context of the whole document. The embReq sets the parameters of the
+// request: its Input is replaced by the chunks and LateChunking is enabled.
+// The returned embeddings are in the order of chunks.
+// NOTE: the whole document must fit within the model context length.
+func (c *Client) EmbedChunks(ctx context.Context, s embeddings.TextSplitter, doc string, embReq *EmbeddingRequest) ([]ChunkEmbedding, error) {
+	chunks := s.Split(doc)
+	if len(chunks) == 0 {
+		return []ChunkEmbedding{}, nil
+	}
+
+	req := *embReq
+	req.Input = TextInputs(chunks...)
+	req.LateChunking = true
+
+	embs, err := c.Embed(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	if len(embs) != len(chunks) {
+		return nil, ErrInValidData
+	}
+
+	chunkEmbs := make([]ChunkEmbedding, 0, len(chunks))
+	for i, emb := range embs {
+		chunkEmbs = append(chunkEmbs, ChunkEmbedding{
+			Embedding: emb,
+			Chunk:     chunks[i],
+		})
+	}
+	return chunkEmbs, nil
+}
@@ -0,0 +1,63 @@
+package jina
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings/document/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedChunks(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := new(struct {
+			Input        []string `json:"input"`
+			Task         Task     `json:"task"`
+			LateChunking bool     `json:"late_chunking"`
+		})
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		assert.True(t, req.LateChunking)
+		assert.Equal(t, req.Task, RetrPassageTask)
+
+		resp := &EmbeddingResponse{Object: "list", Model: EmbeddingsV3}
+		for i, input := range req.Input {
+			resp.Data = append(resp.Data, Data{
+				Object:    "embedding",
+				Index:     i,
+				Embedding: []float64{float64(len(input))},
+			})
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	s := text.NewRecursiveCharSplitter().
+		WithSplitter(text.NewSplitter().WithChunkSize(10).WithChunkOverlap(0))
+
+	doc := "foo bar baz\n\nquux quuz corge"
+	c := NewClient(WithAPIKey(jinaAPIKey), WithBaseURL(srv.URL))
+	embs, err := c.EmbedChunks(context.Background(), s, doc, &EmbeddingRequest{
+		Model: EmbeddingsV3,
+		Task:  RetrPassageTask,
+	})
+	assert.NoError(t, err)
+
+	chunks := s.Split(doc)
+	assert.Greater(t, len(chunks), 1)
+	assert.Len(t, embs, len(chunks))
+	for i, emb := range embs {
+		assert.Equal(t, emb.Chunk, chunks[i])
+		assert.True(t, strings.Contains(doc, emb.Chunk))
+		assert.Equal(t, emb.Vector, []float64{float64(len(chunks[i]))})
+	}
+
+	embs, err = c.EmbedChunks(context.Background(), s, "", &EmbeddingRequest{Model: EmbeddingsV3})
+	assert.NoError(t, err)
+	assert.Empty(t, embs)
+}
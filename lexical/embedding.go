@@ -0,0 +1,86 @@
+package lexical
+
+import (
+	"context"
+	"math"
+
+	"github.com/milosgajdos/go-embeddings"
+)
+
+// EmbeddingRequest contains the texts to embed.
+type EmbeddingRequest struct {
+	Input []string
+}
+
+// NewEmbedder creates a vectorizer that implements embeddings.Embedder
+func NewEmbedder(opts ...Option) embeddings.Embedder[*EmbeddingRequest] {
+	return NewVectorizer(opts...)
+}
+
+// NewSparseEmbedder creates a vectorizer that implements embeddings.SparseEmbedder
+func NewSparseEmbedder(opts ...Option) embeddings.SparseEmbedder[*EmbeddingRequest] {
+	return NewVectorizer(opts...)
+}
+
+// Embed returns L2 normalized dense embeddings for every text in EmbeddingRequest.
+func (v *Vectorizer) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.Embedding, error) {
+	sparse, err := v.SparseEmbed(ctx, embReq)
+	if err != nil {
+		return nil, err
+	}
+
+	embs := make([]*embeddings.Embedding, 0, len(sparse))
+	for _, s := range sparse {
+		emb, err := s.Dense(v.opts.Dims)
+		if err != nil {
+			return nil, err
+		}
+		embs = append(embs, emb)
+	}
+	return embs, nil
+}
+
+// SparseEmbed returns L2 normalized sparse embeddings for every text in EmbeddingRequest.
+func (v *Vectorizer) SparseEmbed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.SparseEmbedding, error) {
+	if v.opts.Dims <= 0 {
+		return nil, ErrInvalidDims
+	}
+	if idf := v.opts.IDF; idf != nil && idf.Dims != v.opts.Dims {
+		return nil, embeddings.ErrDimMismatch
+	}
+
+	embs := make([]*embeddings.SparseEmbedding, 0, len(embReq.Input))
+	for _, input := range embReq.Input {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		emb := embeddings.NewSparseEmbedding(v.weights(input))
+		emb.Dims = v.opts.Dims
+		embs = append(embs, emb)
+	}
+	return embs, nil
+}
+
+// weights returns the L2 normalized feature weights of the text.
+func (v *Vectorizer) weights(s string) map[int]float64 {
+	w := v.termFreqs(s)
+
+	var norm float64
+	for idx, tf := range w {
+		if v.opts.SublinearTF {
+			tf = 1 + math.Log(tf)
+		}
+		if v.opts.IDF != nil {
+			tf *= v.opts.IDF.Weight(idx)
+		}
+		w[idx] = tf
+		norm += tf * tf
+	}
+
+	if norm = math.Sqrt(norm); norm > 0 {
+		for idx := range w {
+			w[idx] /= norm
+		}
+	}
+	return w
+}
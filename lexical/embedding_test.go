@@ -0,0 +1,73 @@
+package lexical
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	embReq := &EmbeddingRequest{Input: []string{"the quick brown fox", "the lazy dog", ""}}
+
+	e := NewEmbedder(WithDims(64), WithCharNGrams(3, 4))
+	embs, err := e.Embed(ctx, embReq)
+	assert.NoError(t, err)
+	assert.Len(t, embs, 3)
+	for _, emb := range embs[:2] {
+		assert.Len(t, emb.Vector, 64)
+		var norm float64
+		for _, f := range emb.Vector {
+			norm += f * f
+		}
+		assert.InDelta(t, 1.0, norm, 1e-12)
+	}
+	assert.Equal(t, make([]float64, 64), embs[2].Vector)
+
+	again, err := e.Embed(ctx, embReq)
+	assert.NoError(t, err)
+	assert.Equal(t, embs, again)
+
+	s := NewSparseEmbedder(WithDims(64), WithCharNGrams(3, 4))
+	sparse, err := s.SparseEmbed(ctx, embReq)
+	assert.NoError(t, err)
+	assert.Len(t, sparse, 3)
+	assert.Equal(t, sparse[0].Dims, 64)
+	dense, err := sparse[0].Dense(64)
+	assert.NoError(t, err)
+	assert.Equal(t, embs[0].Vector, dense.Vector)
+	assert.Equal(t, 0, sparse[2].Len())
+}
+
+func TestEmbedIDF(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v := NewVectorizer(WithDims(1<<16), WithSublinearTF(true))
+	_, err := v.Fit([]string{"foo bar", "foo baz", "foo qux"})
+	assert.NoError(t, err)
+
+	embs, err := v.SparseEmbed(ctx, &EmbeddingRequest{Input: []string{"foo foo bar"}})
+	assert.NoError(t, err)
+	m := embs[0].Map()
+	foo, bar := m[hash("w:foo", 1<<16)], m[hash("w:bar", 1<<16)]
+	// NOTE: foo has sublinear tf 1+ln(2) and idf 1, bar has tf 1 and idf 1+ln(2)
+	assert.InDelta(t, foo, bar, 1e-12)
+	assert.InDelta(t, 1/math.Sqrt(2), foo, 1e-12)
+
+	_, err = NewVectorizer(WithDims(8), WithIDF(v.opts.IDF)).Embed(ctx, &EmbeddingRequest{Input: []string{"foo"}})
+	assert.ErrorIs(t, err, embeddings.ErrDimMismatch)
+
+	_, err = NewVectorizer(WithDims(0)).Embed(ctx, &EmbeddingRequest{Input: []string{"foo"}})
+	assert.ErrorIs(t, err, ErrInvalidDims)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = v.Embed(canceled, &EmbeddingRequest{Input: []string{"foo"}})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,10 @@
+package lexical
+
+import "errors"
+
+var (
+	// ErrInvalidDims is returned when the number of dimensions is not positive.
+	ErrInvalidDims = errors.New("invalid dimensions")
+	// ErrInvalidIDF is returned when the IDF weights are malformed.
+	ErrInvalidIDF = errors.New("invalid IDF")
+)
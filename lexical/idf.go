@@ -0,0 +1,87 @@
+package lexical
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+)
+
+// IDF stores the document frequencies of hashed
+// features used for inverse document frequency weighting.
+type IDF struct {
+	// Dims is the number of dimensions
+	// the features were hashed into.
+	Dims int `json:"dims"`
+	// Docs is the number of fitted documents.
+	Docs int `json:"docs"`
+	// DF is the number of documents
+	// containing the hashed features.
+	DF map[int]int `json:"df"`
+}
+
+// Weight returns the smoothed IDF weight of the hashed feature:
+// ln((1+Docs)/(1+DF)) + 1.
+func (i *IDF) Weight(idx int) float64 {
+	return math.Log(float64(1+i.Docs)/float64(1+i.DF[idx])) + 1
+}
+
+// Validate returns ErrInvalidIDF if the IDF is malformed.
+func (i *IDF) Validate() error {
+	if i.Dims <= 0 || i.Docs < 0 {
+		return ErrInvalidIDF
+	}
+	for idx, df := range i.DF {
+		if idx < 0 || idx >= i.Dims || df < 0 || df > i.Docs {
+			return ErrInvalidIDF
+		}
+	}
+	return nil
+}
+
+// Save saves the IDF into the file at path encoded as JSON.
+func (i *IDF) Save(path string) error {
+	data, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// LoadIDF loads the IDF saved in the file at path.
+func LoadIDF(path string) (*IDF, error) {
+	data, err := os.ReadFile(path) // nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+	idf := new(IDF)
+	if err := json.Unmarshal(data, idf); err != nil {
+		return nil, err
+	}
+	if err := idf.Validate(); err != nil {
+		return nil, err
+	}
+	return idf, nil
+}
+
+// Fit fits the IDF weights on the corpus documents,
+// sets them as the vectorizer IDF and returns them.
+// NOTE: Fit must not be called concurrently with Embed.
+func (v *Vectorizer) Fit(docs []string) (*IDF, error) {
+	if v.opts.Dims <= 0 {
+		return nil, ErrInvalidDims
+	}
+
+	idf := &IDF{
+		Dims: v.opts.Dims,
+		Docs: len(docs),
+		DF:   make(map[int]int),
+	}
+	for _, doc := range docs {
+		for idx := range v.termFreqs(doc) {
+			idf.DF[idx]++
+		}
+	}
+	v.opts.IDF = idf
+
+	return idf, nil
+}
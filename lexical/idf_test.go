@@ -0,0 +1,39 @@
+package lexical
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDF(t *testing.T) {
+	t.Parallel()
+
+	v := NewVectorizer(WithDims(1 << 16))
+	idf, err := v.Fit([]string{"foo bar", "foo baz", "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, idf.Docs, 3)
+	assert.Equal(t, v.opts.IDF, idf)
+
+	foo, bar := hash("w:foo", 1<<16), hash("w:bar", 1<<16)
+	assert.Equal(t, idf.DF[foo], 3)
+	assert.Equal(t, idf.DF[bar], 1)
+	assert.Equal(t, idf.Weight(foo), 1.0)
+	assert.InDelta(t, idf.Weight(bar), math.Log(2)+1, 1e-12)
+
+	path := filepath.Join(t.TempDir(), "idf.json")
+	assert.NoError(t, idf.Save(path))
+	loaded, err := LoadIDF(path)
+	assert.NoError(t, err)
+	assert.Equal(t, idf, loaded)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"dims":4,"docs":1,"df":{"5":1}}`), 0o600))
+	_, err = LoadIDF(path)
+	assert.ErrorIs(t, err, ErrInvalidIDF)
+
+	_, err = NewVectorizer(WithDims(0)).Fit([]string{"foo"})
+	assert.ErrorIs(t, err, ErrInvalidDims)
+}
@@ -0,0 +1,199 @@
+// Package lexical implements a local embedder which needs no model or network.
+// It embeds texts by hashing their word and character n-grams into vectors
+// with a fixed number of dimensions, optionally weighted by TF-IDF.
+package lexical
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"github.com/milosgajdos/go-embeddings/document/text"
+)
+
+const (
+	// DefaultDims is the default number of embedding dimensions.
+	DefaultDims = 4096
+)
+
+// Vectorizer embeds texts by feature hashing.
+type Vectorizer struct {
+	opts Options
+}
+
+// Options are vectorizer options
+type Options struct {
+	// Dims is the number of embedding dimensions.
+	Dims int
+	// MinWordN and MaxWordN are the word n-gram sizes.
+	// Word n-grams are disabled if MaxWordN is 0.
+	MinWordN int
+	MaxWordN int
+	// MinCharN and MaxCharN are the character n-gram sizes.
+	// Character n-grams are disabled if MaxCharN is 0.
+	MinCharN int
+	MaxCharN int
+	// LenFunc measures the length of words and character n-grams.
+	LenFunc text.LenFunc
+	// MinWordLen is the minimum length of the hashed words.
+	MinWordLen int
+	// Lowercase the texts before hashing.
+	Lowercase bool
+	// SublinearTF replaces term frequency tf with 1+ln(tf).
+	SublinearTF bool
+	// IDF weights the term frequencies if set.
+	IDF *IDF
+}
+
+// Option is functional option.
+type Option func(*Options)
+
+// NewVectorizer creates a new hashing vectorizer and returns it.
+// By default it hashes lowercased words into DefaultDims dimensions
+// and measures lengths with text.DefaultLenFunc.
+func NewVectorizer(opts ...Option) *Vectorizer {
+	options := Options{
+		Dims:       DefaultDims,
+		MinWordN:   1,
+		MaxWordN:   1,
+		LenFunc:    text.DefaultLenFunc,
+		MinWordLen: 1,
+		Lowercase:  true,
+	}
+
+	for _, apply := range opts {
+		apply(&options)
+	}
+
+	return &Vectorizer{
+		opts: options,
+	}
+}
+
+// WithDims sets the number of embedding dimensions.
+func WithDims(dims int) Option {
+	return func(o *Options) {
+		o.Dims = dims
+	}
+}
+
+// WithWordNGrams sets the word n-gram sizes.
+func WithWordNGrams(minN, maxN int) Option {
+	return func(o *Options) {
+		o.MinWordN = minN
+		o.MaxWordN = maxN
+	}
+}
+
+// WithCharNGrams sets the character n-gram sizes.
+func WithCharNGrams(minN, maxN int) Option {
+	return func(o *Options) {
+		o.MinCharN = minN
+		o.MaxCharN = maxN
+	}
+}
+
+// WithLenFunc sets the length func.
+func WithLenFunc(f text.LenFunc) Option {
+	return func(o *Options) {
+		o.LenFunc = f
+	}
+}
+
+// WithMinWordLen sets the minimum length of the hashed words.
+func WithMinWordLen(n int) Option {
+	return func(o *Options) {
+		o.MinWordLen = n
+	}
+}
+
+// WithLowercase enables lowercasing the texts.
+func WithLowercase(lowercase bool) Option {
+	return func(o *Options) {
+		o.Lowercase = lowercase
+	}
+}
+
+// WithSublinearTF enables sublinear term frequency scaling.
+func WithSublinearTF(sublinear bool) Option {
+	return func(o *Options) {
+		o.SublinearTF = sublinear
+	}
+}
+
+// WithIDF sets the IDF weights.
+func WithIDF(idf *IDF) Option {
+	return func(o *Options) {
+		o.IDF = idf
+	}
+}
+
+// words splits the text into words.
+func (v *Vectorizer) words(s string) []string {
+	if v.opts.Lowercase {
+		s = strings.ToLower(s)
+	}
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	words := fields[:0]
+	for _, w := range fields {
+		if v.opts.LenFunc(w) >= v.opts.MinWordLen {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// features returns the n-gram features of the text.
+// Word n-grams are joined by space. Character n-grams are
+// extracted from every word padded by space, and they consist
+// of whole characters spanning at least n units of LenFunc.
+func (v *Vectorizer) features(s string) []string {
+	words := v.words(s)
+
+	// nolint:prealloc
+	var feats []string
+	if v.opts.MaxWordN > 0 {
+		for n := max(v.opts.MinWordN, 1); n <= v.opts.MaxWordN; n++ {
+			for i := 0; i+n <= len(words); i++ {
+				feats = append(feats, "w:"+strings.Join(words[i:i+n], " "))
+			}
+		}
+	}
+
+	if v.opts.MaxCharN > 0 {
+		for _, w := range words {
+			runes := []rune(" " + w + " ")
+			for n := max(v.opts.MinCharN, 1); n <= v.opts.MaxCharN; n++ {
+				for i := range runes {
+					for j := i + 1; j <= len(runes); j++ {
+						gram := string(runes[i:j])
+						if v.opts.LenFunc(gram) >= n {
+							feats = append(feats, "c:"+gram)
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return feats
+}
+
+// termFreqs returns the hashed term frequencies of the text.
+func (v *Vectorizer) termFreqs(s string) map[int]float64 {
+	tf := make(map[int]float64)
+	for _, f := range v.features(s) {
+		tf[hash(f, v.opts.Dims)]++
+	}
+	return tf
+}
+
+// hash hashes the feature into dims buckets.
+func hash(feature string, dims int) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(feature))
+	return int(h.Sum64() % uint64(dims)) // nolint:gosec
+}
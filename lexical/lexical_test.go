@@ -0,0 +1,81 @@
+package lexical
+
+import (
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings/document/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorizer(t *testing.T) {
+	t.Parallel()
+
+	v := NewVectorizer()
+	assert.Equal(t, v.opts.Dims, DefaultDims)
+	assert.True(t, v.opts.Lowercase)
+
+	v = NewVectorizer(
+		WithDims(16),
+		WithWordNGrams(1, 2),
+		WithCharNGrams(2, 3),
+		WithMinWordLen(2),
+		WithLowercase(false),
+		WithSublinearTF(true),
+		WithLenFunc(text.StringBytesLenFunc),
+	)
+	assert.Equal(t, v.opts.Dims, 16)
+	assert.Equal(t, [4]int{v.opts.MinWordN, v.opts.MaxWordN, v.opts.MinCharN, v.opts.MaxCharN}, [4]int{1, 2, 2, 3})
+	assert.Equal(t, v.opts.MinWordLen, 2)
+	assert.False(t, v.opts.Lowercase)
+	assert.True(t, v.opts.SublinearTF)
+}
+
+func TestFeatures(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		opts []Option
+		text string
+		exp  []string
+	}{
+		{
+			name: "words",
+			text: "Foo, bar! foo",
+			exp:  []string{"w:foo", "w:bar", "w:foo"},
+		},
+		{
+			name: "bigrams",
+			opts: []Option{WithWordNGrams(1, 2), WithLowercase(false)},
+			text: "Foo bar baz",
+			exp:  []string{"w:Foo", "w:bar", "w:baz", "w:Foo bar", "w:bar baz"},
+		},
+		{
+			name: "min word len",
+			opts: []Option{WithMinWordLen(3)},
+			text: "a ab abc",
+			exp:  []string{"w:abc"},
+		},
+		{
+			name: "char runes",
+			opts: []Option{WithWordNGrams(0, 0), WithCharNGrams(3, 3)},
+			text: "čau",
+			exp:  []string{"c: ča", "c:čau", "c:au "},
+		},
+		{
+			name: "char bytes",
+			opts: []Option{WithWordNGrams(0, 0), WithCharNGrams(3, 3), WithLenFunc(text.StringBytesLenFunc)},
+			text: "čau",
+			exp:  []string{"c: č", "c:ča", "c:au "},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			v := NewVectorizer(tc.opts...)
+			assert.Equal(t, tc.exp, v.features(tc.text))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package llamacpp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/milosgajdos/go-embeddings/request"
+)
+
+// post sends the payload to the given API endpoint
+// and decodes the API response into resp.
+func (c *Client) post(ctx context.Context, endpoint string, payload, resp any) error {
+	return c.send(ctx, http.MethodPost, endpoint, payload, resp)
+}
+
+// send sends the payload to the given API endpoint using the given
+// HTTP method and decodes the API response into resp.
+// The payload is not sent if it's nil.
+func (c *Client) send(ctx context.Context, method, endpoint string, payload, resp any) error {
+	u, err := url.Parse(c.opts.BaseURL + endpoint)
+	if err != nil {
+		return err
+	}
+
+	var body = &bytes.Buffer{}
+	if payload != nil {
+		enc := json.NewEncoder(body)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(payload); err != nil {
+			return err
+		}
+	}
+
+	var options []request.Option
+	if c.opts.APIKey != "" {
+		options = append(options, request.WithBearer(c.opts.APIKey))
+	}
+
+	req, err := request.NewHTTP(ctx, method, u.String(), body, options...)
+	if err != nil {
+		return err
+	}
+
+	res, err := request.Do[APIError](c.opts.HTTPClient, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return json.NewDecoder(res.Body).Decode(resp)
+}
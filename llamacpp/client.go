@@ -0,0 +1,87 @@
+package llamacpp
+
+import (
+	"os"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/milosgajdos/go-embeddings/client"
+)
+
+const (
+	// BaseURL is the default llama.cpp server HTTP API base URL.
+	BaseURL = "http://localhost:8080"
+)
+
+// Client is llama.cpp server HTTP API client.
+type Client struct {
+	opts Options
+}
+
+// Options are client options
+type Options struct {
+	// APIKey is only required if
+	// the server was started with one.
+	APIKey     string
+	BaseURL    string
+	HTTPClient *client.HTTP
+	// MeanPool makes Embed mean-pool the token
+	// embeddings returned with pooling disabled.
+	MeanPool bool
+}
+
+// Option is functional option.
+type Option func(*Options)
+
+// NewClient creates a new HTTP API client and returns it.
+// By default it reads the optional server API key from LLAMACPP_API_KEY
+// env var and uses the default Go http.Client for making API requests.
+// You can override the default options via the client methods.
+func NewClient(opts ...Option) *Client {
+	options := Options{
+		APIKey:     os.Getenv("LLAMACPP_API_KEY"),
+		BaseURL:    BaseURL,
+		HTTPClient: client.NewHTTP(),
+	}
+
+	for _, apply := range opts {
+		apply(&options)
+	}
+
+	return &Client{
+		opts: options,
+	}
+}
+
+// NewEmbedder creates a client that implements embeddings.Embedder
+func NewEmbedder(opts ...Option) embeddings.Embedder[*EmbeddingRequest] {
+	return NewClient(opts...)
+}
+
+// WithAPIKey sets the API key.
+func WithAPIKey(apiKey string) Option {
+	return func(o *Options) {
+		o.APIKey = apiKey
+	}
+}
+
+// WithBaseURL sets the API base URL.
+func WithBaseURL(baseURL string) Option {
+	return func(o *Options) {
+		o.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client.
+func WithHTTPClient(httpClient *client.HTTP) Option {
+	return func(o *Options) {
+		o.HTTPClient = httpClient
+	}
+}
+
+// WithMeanPool enables client-side mean pooling
+// of the token embeddings returned by Embed.
+func WithMeanPool(meanPool bool) Option {
+	return func(o *Options) {
+		o.MeanPool = meanPool
+	}
+}
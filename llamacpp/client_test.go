@@ -0,0 +1,51 @@
+package llamacpp
+
+import (
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings/client"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	llamacppAPIKey = "somekey"
+)
+
+func TestClient(t *testing.T) {
+	t.Setenv("LLAMACPP_API_KEY", llamacppAPIKey)
+
+	t.Run("API key", func(t *testing.T) {
+		c := NewClient()
+		assert.Equal(t, c.opts.APIKey, llamacppAPIKey)
+
+		testVal := "foo"
+		c = NewClient(WithAPIKey(testVal))
+		assert.Equal(t, c.opts.APIKey, testVal)
+	})
+
+	t.Run("BaseURL", func(t *testing.T) {
+		c := NewClient()
+		assert.Equal(t, c.opts.BaseURL, BaseURL)
+
+		testVal := "http://foo"
+		c = NewClient(WithBaseURL(testVal))
+		assert.Equal(t, c.opts.BaseURL, testVal)
+	})
+
+	t.Run("http client", func(t *testing.T) {
+		c := NewClient()
+		assert.NotNil(t, c.opts.HTTPClient)
+
+		testVal := client.NewHTTP()
+		c = NewClient(WithHTTPClient(testVal))
+		assert.NotNil(t, c.opts.HTTPClient)
+	})
+
+	t.Run("mean pool", func(t *testing.T) {
+		c := NewClient()
+		assert.False(t, c.opts.MeanPool)
+
+		c = NewClient(WithMeanPool(true))
+		assert.True(t, c.opts.MeanPool)
+	})
+}
@@ -0,0 +1,137 @@
+package llamacpp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/milosgajdos/go-embeddings"
+)
+
+// EmbeddingRequest is sent to the embedding API endpoint.
+// https://github.com/ggml-org/llama.cpp/tree/master/tools/server#post-embedding-get-embeddings-from-the-model
+type EmbeddingRequest struct {
+	// Content is either a string, a slice of strings,
+	// a slice of tokens or a slice of token slices.
+	Content any `json:"content"`
+	// Normalize sets the embeddings normalization.
+	// It defaults to EuclideanNorm on the server.
+	// NOTE: only pooled embeddings are normalized.
+	Normalize *Normalization `json:"embd_normalize,omitempty"`
+}
+
+// Data stores the embeddings of a single input.
+// It contains a single pooled embedding or the embedding
+// of every input token if the server pooling is disabled.
+type Data struct {
+	Index     int         `json:"index"`
+	Embedding [][]float64 `json:"embedding"`
+}
+
+// EmbeddingResponse is the embedding API response.
+type EmbeddingResponse []Data
+
+// ToEmbeddings converts the API response,
+// into a slice of embeddings and returns it.
+// It returns embeddings.ErrUnpooled if the
+// response contains unpooled token embeddings.
+func (e EmbeddingResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	mvs, err := e.ToMultiVectors()
+	if err != nil {
+		return nil, err
+	}
+	return embeddings.PooledEmbeddings(mvs)
+}
+
+// ToTokenEmbeddings converts the API response,
+// into a slice of token embeddings for every input.
+func (e EmbeddingResponse) ToTokenEmbeddings() ([][]*embeddings.Embedding, error) {
+	mvs, err := e.ToMultiVectors()
+	if err != nil {
+		return nil, err
+	}
+	return embeddings.TokenEmbeddings(mvs), nil
+}
+
+// ToMultiVectors converts the API response,
+// into a multi-vector embedding for every input.
+func (e EmbeddingResponse) ToMultiVectors() ([]*embeddings.MultiVector, error) {
+	tokens := make([][][]float64, 0, len(e))
+	for _, d := range e {
+		tokens = append(tokens, d.Embedding)
+	}
+	return embeddings.NewMultiVectors(tokens), nil
+}
+
+// MeanPool mean-pools the embeddings of every input and returns them.
+// Pooled embeddings are returned unchanged.
+// NOTE: the mean-pooled embeddings are not normalized.
+func (e EmbeddingResponse) MeanPool() ([]*embeddings.Embedding, error) {
+	mvs, err := e.ToMultiVectors()
+	if err != nil {
+		return nil, err
+	}
+	return embeddings.MeanPoolEmbeddings(mvs)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It decodes both the current server responses, which are
+// lists of input embeddings, and the legacy single embedding responses.
+func (e *EmbeddingResponse) UnmarshalJSON(data []byte) error {
+	type rawData struct {
+		Index     int             `json:"index"`
+		Embedding json.RawMessage `json:"embedding"`
+	}
+
+	var raws []rawData
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '{' {
+		var raw rawData
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		raws = append(raws, raw)
+	} else if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+
+	resp := make(EmbeddingResponse, 0, len(raws))
+	for _, raw := range raws {
+		d := Data{Index: raw.Index}
+		if err := json.Unmarshal(raw.Embedding, &d.Embedding); err != nil {
+			// NOTE: pooled embeddings used to be returned unnested
+			var vals []float64
+			if err := json.Unmarshal(raw.Embedding, &vals); err != nil {
+				return ErrInValidData
+			}
+			d.Embedding = [][]float64{vals}
+		}
+		resp = append(resp, d)
+	}
+	*e = resp
+
+	return nil
+}
+
+// Embed returns embeddings for every object in EmbeddingRequest.
+// The token embeddings are mean-pooled if the client MeanPool
+// option is enabled, otherwise embeddings.ErrUnpooled is returned for them.
+func (c *Client) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.Embedding, error) {
+	embs, err := c.Embeddings(ctx, embReq)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.MeanPool {
+		return embs.MeanPool()
+	}
+	return embs.ToEmbeddings()
+}
+
+// Embeddings fetches embeddings for every object in EmbeddingRequest.
+// NOTE: the server also serves the same API on the /embeddings endpoint.
+func (c *Client) Embeddings(ctx context.Context, embReq *EmbeddingRequest) (EmbeddingResponse, error) {
+	var embs EmbeddingResponse
+	if err := c.post(ctx, "/embedding", embReq, &embs); err != nil {
+		return nil, err
+	}
+	return embs, nil
+}
@@ -0,0 +1,132 @@
+package llamacpp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddingResponse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		data string
+		exp  EmbeddingResponse
+	}{
+		{
+			name: "pooled",
+			data: `[{"index":0,"embedding":[[1.0,2.0]]},{"index":1,"embedding":[[3.0,4.0]]}]`,
+			exp:  EmbeddingResponse{{Index: 0, Embedding: [][]float64{{1, 2}}}, {Index: 1, Embedding: [][]float64{{3, 4}}}},
+		},
+		{
+			name: "unpooled",
+			data: `[{"index":0,"embedding":[[1.0,2.0],[3.0,4.0]]}]`,
+			exp:  EmbeddingResponse{{Index: 0, Embedding: [][]float64{{1, 2}, {3, 4}}}},
+		},
+		{
+			name: "legacy",
+			data: `{"embedding":[1.0,2.0]}`,
+			exp:  EmbeddingResponse{{Index: 0, Embedding: [][]float64{{1, 2}}}},
+		},
+		{
+			name: "unnested",
+			data: `[{"index":0,"embedding":[1.0,2.0]}]`,
+			exp:  EmbeddingResponse{{Index: 0, Embedding: [][]float64{{1, 2}}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var resp EmbeddingResponse
+			assert.NoError(t, json.Unmarshal([]byte(tc.data), &resp))
+			assert.Equal(t, tc.exp, resp)
+		})
+	}
+
+	var resp EmbeddingResponse
+	assert.ErrorIs(t, json.Unmarshal([]byte(`[{"index":0,"embedding":"foo"}]`), &resp), ErrInValidData)
+}
+
+func TestPooling(t *testing.T) {
+	t.Parallel()
+
+	resp := EmbeddingResponse{
+		{Index: 0, Embedding: [][]float64{{1, 2}, {3, 4}, {5, 6}}},
+		{Index: 1, Embedding: [][]float64{{1, 1}}},
+	}
+
+	_, err := resp.ToEmbeddings()
+	assert.ErrorIs(t, err, embeddings.ErrUnpooled)
+
+	tokens, err := resp.ToTokenEmbeddings()
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 2)
+	assert.Len(t, tokens[0], 3)
+	assert.Equal(t, tokens[0][1].Vector, []float64{3, 4})
+
+	mvs, err := resp.ToMultiVectors()
+	assert.NoError(t, err)
+	assert.Len(t, mvs, 2)
+	assert.Equal(t, mvs[0].Len(), 3)
+	assert.Equal(t, mvs[1].Vectors, [][]float64{{1, 1}})
+
+	embs, err := resp.MeanPool()
+	assert.NoError(t, err)
+	assert.Equal(t, embs[0].Vector, []float64{3, 4})
+	assert.Equal(t, embs[1].Vector, []float64{1, 1})
+
+	_, err = EmbeddingResponse{{Embedding: [][]float64{{1, 2}, {3}}}}.MeanPool()
+	assert.ErrorIs(t, err, embeddings.ErrInvalidMultiVector)
+}
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+llamacppAPIKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":401,"message":"Invalid API Key","type":"authentication_error"}}`))
+			return
+		}
+		assert.Equal(t, r.URL.Path, "/embedding")
+
+		req := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, req["content"], []any{"foo", "bar"})
+		assert.Equal(t, req["embd_normalize"], float64(NoNorm))
+		_, _ = w.Write([]byte(`[{"index":0,"embedding":[[1.0,2.0],[3.0,4.0]]},{"index":1,"embedding":[[5.0,6.0]]}]`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	norm := NoNorm
+	embReq := &EmbeddingRequest{
+		Content:   []string{"foo", "bar"},
+		Normalize: &norm,
+	}
+
+	c := NewEmbedder(WithAPIKey(llamacppAPIKey), WithBaseURL(srv.URL))
+	_, err := c.Embed(ctx, embReq)
+	assert.ErrorIs(t, err, embeddings.ErrUnpooled)
+
+	c = NewEmbedder(WithAPIKey(llamacppAPIKey), WithBaseURL(srv.URL), WithMeanPool(true))
+	embs, err := c.Embed(ctx, embReq)
+	assert.NoError(t, err)
+	assert.Len(t, embs, 2)
+	assert.Equal(t, embs[0].Vector, []float64{2, 3})
+	assert.Equal(t, embs[1].Vector, []float64{5, 6})
+
+	c = NewEmbedder(WithAPIKey("foo"), WithBaseURL(srv.URL))
+	_, err = c.Embed(ctx, embReq)
+	var apiErr APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, apiErr.Err.Code, http.StatusUnauthorized)
+}
@@ -0,0 +1,29 @@
+package llamacpp
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrInValidData is returned when the API client fails to decode the returned data.
+	ErrInValidData = errors.New("invalid data")
+)
+
+// APIError is llama.cpp server API error.
+type APIError struct {
+	Err struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"error"`
+}
+
+// Error implements error interface.
+func (e APIError) Error() string {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "unknown error"
+	}
+	return string(b)
+}
@@ -0,0 +1,12 @@
+package llamacpp
+
+// Normalization is the embeddings normalization.
+type Normalization int
+
+const (
+	NoNorm Normalization = -1
+	// MaxAbsNorm scales the embeddings into int16 range.
+	MaxAbsNorm    Normalization = 0
+	TaxicabNorm   Normalization = 1
+	EuclideanNorm Normalization = 2
+)
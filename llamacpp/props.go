@@ -0,0 +1,30 @@
+package llamacpp
+
+import (
+	"context"
+	"net/http"
+)
+
+// GenerationSettings are the server default generation settings.
+type GenerationSettings struct {
+	NCtx   int            `json:"n_ctx"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// Props are the server properties.
+type Props struct {
+	DefaultGenerationSettings GenerationSettings `json:"default_generation_settings"`
+	TotalSlots                int                `json:"total_slots"`
+	ModelPath                 string             `json:"model_path"`
+	ChatTemplate              string             `json:"chat_template,omitempty"`
+	BuildInfo                 string             `json:"build_info,omitempty"`
+}
+
+// Props returns the server properties.
+func (c *Client) Props(ctx context.Context) (*Props, error) {
+	props := new(Props)
+	if err := c.send(ctx, http.MethodGet, "/props", nil, props); err != nil {
+		return nil, err
+	}
+	return props, nil
+}
@@ -0,0 +1,28 @@
+package llamacpp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProps(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/props")
+		assert.Equal(t, r.Method, http.MethodGet)
+		_, _ = w.Write([]byte(`{"default_generation_settings":{"n_ctx":4096,"params":{"seed":-1}},"total_slots":4,"model_path":"nomic-embed-text-v1.5.Q8_0.gguf","build_info":"b4000"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	props, err := c.Props(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, props.DefaultGenerationSettings.NCtx, 4096)
+	assert.Equal(t, props.TotalSlots, 4)
+	assert.Equal(t, props.ModelPath, "nomic-embed-text-v1.5.Q8_0.gguf")
+}
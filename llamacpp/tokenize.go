@@ -0,0 +1,51 @@
+package llamacpp
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// TokenizeRequest is sent to the tokenize API endpoint.
+type TokenizeRequest struct {
+	Content string `json:"content"`
+	// AddSpecial adds the model special tokens e.g. BOS.
+	AddSpecial bool `json:"add_special,omitempty"`
+	// WithPieces returns the token pieces too.
+	WithPieces bool `json:"with_pieces,omitempty"`
+}
+
+// Token is a single content token.
+type Token struct {
+	ID int `json:"id"`
+	// Piece is only returned if requested.
+	// NOTE: pieces which are not valid UTF-8
+	// are returned as byte values by the server.
+	Piece any `json:"piece,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Tokens are returned as plain IDs unless
+// their pieces were requested.
+func (t *Token) UnmarshalJSON(data []byte) error {
+	var id int
+	if err := json.Unmarshal(data, &id); err == nil {
+		*t = Token{ID: id}
+		return nil
+	}
+	type token Token
+	return json.Unmarshal(data, (*token)(t))
+}
+
+// TokenizeResponse is the tokenize API response.
+type TokenizeResponse struct {
+	Tokens []Token `json:"tokens"`
+}
+
+// Tokenize tokenizes the content using the tokenizer of the served model.
+func (c *Client) Tokenize(ctx context.Context, tokReq *TokenizeRequest) (*TokenizeResponse, error) {
+	tokens := new(TokenizeResponse)
+	if err := c.post(ctx, "/tokenize", tokReq, tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
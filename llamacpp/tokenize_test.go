@@ -0,0 +1,38 @@
+package llamacpp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/tokenize")
+		req := new(TokenizeRequest)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		if req.WithPieces {
+			_, _ = w.Write([]byte(`{"tokens":[{"id":1,"piece":"foo"},{"id":2,"piece":[226,130]}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"tokens":[1,2]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	resp, err := c.Tokenize(context.Background(), &TokenizeRequest{Content: "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, resp.Tokens, []Token{{ID: 1}, {ID: 2}})
+
+	resp, err = c.Tokenize(context.Background(), &TokenizeRequest{Content: "foo", WithPieces: true})
+	assert.NoError(t, err)
+	assert.Equal(t, resp.Tokens[0], Token{ID: 1, Piece: "foo"})
+	assert.Equal(t, resp.Tokens[1].ID, 2)
+	assert.NotNil(t, resp.Tokens[1].Piece)
+}
@@ -0,0 +1,112 @@
+package mistral
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/milosgajdos/go-embeddings"
+	"github.com/milosgajdos/go-embeddings/request"
+)
+
+// EmbeddingRequest sent to API endpoint.
+// https://docs.mistral.ai/api/#tag/embeddings
+type EmbeddingRequest struct {
+	// Input is either a string or a slice of strings.
+	Input any   `json:"input"`
+	Model Model `json:"model"`
+	// NOTE: the following parameters are only supported by CodestralEmbed.
+	// OutputDimension of the returned embeddings.
+	OutputDimension int `json:"output_dimension,omitempty"`
+	// OutputDType of the returned embeddings. Defaults to float.
+	// Integer types are returned as integer values and binary
+	// types as int8 or uint8 values of the bit-packed vectors.
+	OutputDType OutputDType `json:"output_dtype,omitempty"`
+}
+
+// Usage tracks API token usage.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+	CompletionTokens int `json:"completion_tokens,omitempty"`
+}
+
+// Data stores vector embeddings.
+type Data struct {
+	Object    string    `json:"object"`
+	Index     int       `json:"index"`
+	Embedding []float64 `json:"embedding"`
+}
+
+// EmbeddingResponse received from API.
+type EmbeddingResponse struct {
+	ID     string `json:"id"`
+	Object string `json:"object"`
+	Data   []Data `json:"data"`
+	Model  Model  `json:"model"`
+	Usage  Usage  `json:"usage"`
+}
+
+// ToEmbeddings converts the API response,
+// into a slice of embeddings and returns it.
+func (e *EmbeddingResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	embs := make([]*embeddings.Embedding, 0, len(e.Data))
+	for _, d := range e.Data {
+		floats := make([]float64, len(d.Embedding))
+		copy(floats, d.Embedding)
+		emb := &embeddings.Embedding{
+			Vector: floats,
+		}
+		embs = append(embs, emb)
+	}
+	return embs, nil
+}
+
+// Embed returns embeddings for every object in EmbeddingRequest.
+func (c *Client) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.Embedding, error) {
+	embs, err := c.Embeddings(ctx, embReq)
+	if err != nil {
+		return nil, err
+	}
+	return embs.ToEmbeddings()
+}
+
+// Embeddings fetches embeddings for every object in EmbeddingRequest
+// and returns the API response including the token usage.
+func (c *Client) Embeddings(ctx context.Context, embReq *EmbeddingRequest) (*EmbeddingResponse, error) {
+	u, err := url.Parse(c.opts.BaseURL + "/" + c.opts.Version + "/embeddings")
+	if err != nil {
+		return nil, err
+	}
+
+	var body = &bytes.Buffer{}
+	enc := json.NewEncoder(body)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(embReq); err != nil {
+		return nil, err
+	}
+
+	options := []request.Option{
+		request.WithBearer(c.opts.APIKey),
+	}
+
+	req, err := request.NewHTTP(ctx, http.MethodPost, u.String(), body, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := request.Do[APIError](c.opts.HTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	embs := new(EmbeddingResponse)
+	if err := json.NewDecoder(resp.Body).Decode(embs); err != nil {
+		return nil, err
+	}
+
+	return embs, nil
+}
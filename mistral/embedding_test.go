@@ -0,0 +1,92 @@
+package mistral
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1/embeddings")
+		if r.Header.Get("Authorization") != "Bearer "+mistralAPIKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Unauthorized","request_id":"1"}`))
+			return
+		}
+
+		req := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req["model"] {
+		case EmbedV1.String():
+			assert.Equal(t, req["input"], []any{"foo", "bar"})
+			assert.NotContains(t, req, "output_dimension")
+			assert.NotContains(t, req, "output_dtype")
+			_, _ = w.Write([]byte(`{"id":"1","object":"list","data":[{"object":"embedding","index":0,"embedding":[1.0]},{"object":"embedding","index":1,"embedding":[2.0]}],"model":"mistral-embed","usage":{"prompt_tokens":2,"total_tokens":2,"completion_tokens":0}}`))
+		case CodestralEmbed.String():
+			assert.Equal(t, req["input"], "foo")
+			assert.Equal(t, req["output_dimension"], float64(256))
+			assert.Equal(t, req["output_dtype"], Int8DType.String())
+			_, _ = w.Write([]byte(`{"id":"2","object":"list","data":[{"object":"embedding","index":0,"embedding":[-3,4]}],"model":"codestral-embed","usage":{"prompt_tokens":1,"total_tokens":1}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"object":"error","message":"Invalid model: foo","type":"invalid_model","param":null,"code":"1500"}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	t.Run("embed", func(t *testing.T) {
+		c := NewEmbedder(WithAPIKey(mistralAPIKey), WithBaseURL(srv.URL))
+		embs, err := c.Embed(ctx, &EmbeddingRequest{
+			Input: []string{"foo", "bar"},
+			Model: EmbedV1,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, embs, 2)
+		assert.Equal(t, embs[1].Vector, []float64{2.0})
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		c := NewClient(WithAPIKey(mistralAPIKey), WithBaseURL(srv.URL))
+		resp, err := c.Embeddings(ctx, &EmbeddingRequest{
+			Input:           "foo",
+			Model:           CodestralEmbed,
+			OutputDimension: 256,
+			OutputDType:     Int8DType,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, resp.Usage, Usage{PromptTokens: 1, TotalTokens: 1})
+		assert.Equal(t, resp.Data[0].Embedding, []float64{-3, 4})
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		c := NewClient(WithAPIKey(mistralAPIKey), WithBaseURL(srv.URL))
+		_, err := c.Embed(ctx, &EmbeddingRequest{
+			Input: "foo",
+			Model: "foo",
+		})
+		var apiErr APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, apiErr.Message, "Invalid model: foo")
+		assert.Equal(t, apiErr.Type, "invalid_model")
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		c := NewClient(WithAPIKey("bar"), WithBaseURL(srv.URL))
+		_, err := c.Embed(ctx, &EmbeddingRequest{
+			Input: "foo",
+			Model: EmbedV1,
+		})
+		var apiErr APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, apiErr.Message, "Unauthorized")
+	})
+}
@@ -0,0 +1,25 @@
+package mistral
+
+import (
+	"encoding/json"
+)
+
+// APIError is Mistral API error.
+type APIError struct {
+	Object string `json:"object,omitempty"`
+	// Message is either a string or
+	// a detailed request validation error.
+	Message any     `json:"message"`
+	Type    string  `json:"type,omitempty"`
+	Param   *string `json:"param,omitempty"`
+	Code    any     `json:"code,omitempty"`
+}
+
+// Error implements error interface.
+func (e APIError) Error() string {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "unknown error"
+	}
+	return string(b)
+}
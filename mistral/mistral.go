@@ -0,0 +1,32 @@
+package mistral
+
+// Model is an embedding model.
+type Model string
+
+const (
+	EmbedV1 Model = "mistral-embed"
+	// CodestralEmbed is a code embedding model.
+	// It supports custom output dimensions and data types.
+	CodestralEmbed Model = "codestral-embed"
+)
+
+// String implements stringer.
+func (m Model) String() string {
+	return string(m)
+}
+
+// OutputDType is the data type of the returned embeddings.
+type OutputDType string
+
+const (
+	FloatDType   OutputDType = "float"
+	Int8DType    OutputDType = "int8"
+	Uint8DType   OutputDType = "uint8"
+	BinaryDType  OutputDType = "binary"
+	UbinaryDType OutputDType = "ubinary"
+)
+
+// String implements stringer.
+func (d OutputDType) String() string {
+	return string(d)
+}
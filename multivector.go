@@ -0,0 +1,329 @@
+package embeddings
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	// multiVectorVersion is the multi-vector binary encoding version.
+	multiVectorVersion byte = 1
+)
+
+var (
+	// ErrInvalidMultiVector is returned when the multi-vector embedding is malformed.
+	ErrInvalidMultiVector = errors.New("invalid multi-vector embedding")
+	// ErrUnpooled is returned when converting multi-vector
+	// embeddings with many vectors into single embeddings.
+	ErrUnpooled = errors.New("unpooled token embeddings")
+)
+
+// MultiVector is multi-vector embedding.
+// It stores a vector per token as returned by late
+// interaction models e.g. ColBERT or unpooled models.
+type MultiVector struct {
+	Vectors [][]float64 `json:"vectors"`
+}
+
+// NewMultiVector creates a new multi-vector
+// embedding from token embeddings and returns it.
+func NewMultiVector(embs []*Embedding) *MultiVector {
+	vectors := make([][]float64, 0, len(embs))
+	for _, e := range embs {
+		floats := make([]float64, len(e.Vector))
+		copy(floats, e.Vector)
+		vectors = append(vectors, floats)
+	}
+	return &MultiVector{
+		Vectors: vectors,
+	}
+}
+
+// NewMultiVectors creates a new multi-vector embedding
+// from every slice of token vectors and returns them.
+func NewMultiVectors(tokens [][][]float64) []*MultiVector {
+	mvs := make([]*MultiVector, 0, len(tokens))
+	for _, vectors := range tokens {
+		floats := make([][]float64, 0, len(vectors))
+		for _, v := range vectors {
+			floats = append(floats, append([]float64(nil), v...))
+		}
+		mvs = append(mvs, &MultiVector{
+			Vectors: floats,
+		})
+	}
+	return mvs
+}
+
+// PooledEmbeddings returns the single vector of every
+// multi-vector embedding e.g. as returned by pooling models.
+// It returns ErrUnpooled if any of them has many or no vectors.
+func PooledEmbeddings(mvs []*MultiVector) ([]*Embedding, error) {
+	embs := make([]*Embedding, 0, len(mvs))
+	for _, m := range mvs {
+		if m.Len() != 1 {
+			return nil, ErrUnpooled
+		}
+		embs = append(embs, &Embedding{
+			Vector: append([]float64(nil), m.Vectors[0]...),
+		})
+	}
+	return embs, nil
+}
+
+// TokenEmbeddings returns the vectors of every
+// multi-vector embedding as a slice of embeddings.
+func TokenEmbeddings(mvs []*MultiVector) [][]*Embedding {
+	embs := make([][]*Embedding, 0, len(mvs))
+	for _, m := range mvs {
+		tokenEmbs := make([]*Embedding, 0, m.Len())
+		for _, v := range m.Vectors {
+			tokenEmbs = append(tokenEmbs, &Embedding{
+				Vector: append([]float64(nil), v...),
+			})
+		}
+		embs = append(embs, tokenEmbs)
+	}
+	return embs
+}
+
+// MeanPoolEmbeddings mean-pools every multi-vector
+// embedding and returns the pooled embeddings.
+// NOTE: the mean-pooled embeddings are not normalized.
+func MeanPoolEmbeddings(mvs []*MultiVector) ([]*Embedding, error) {
+	embs := make([]*Embedding, 0, len(mvs))
+	for _, m := range mvs {
+		emb, err := m.MeanPool()
+		if err != nil {
+			return nil, err
+		}
+		embs = append(embs, emb)
+	}
+	return embs, nil
+}
+
+// Len returns the number of vectors.
+func (m MultiVector) Len() int {
+	return len(m.Vectors)
+}
+
+// Dims returns the number of vector dimensions.
+func (m MultiVector) Dims() int {
+	if len(m.Vectors) == 0 {
+		return 0
+	}
+	return len(m.Vectors[0])
+}
+
+// Validate returns ErrInvalidMultiVector if the vectors
+// have different dimensions or if they have no dimensions.
+func (m MultiVector) Validate() error {
+	if m.Len() > 0 && m.Dims() == 0 {
+		return ErrInvalidMultiVector
+	}
+	for _, v := range m.Vectors {
+		if len(v) != m.Dims() {
+			return ErrInvalidMultiVector
+		}
+	}
+	return nil
+}
+
+// MaxSim returns the late interaction score of the query
+// m and the document d: the sum of the maximum dot products
+// of every query vector with the document vectors.
+// NOTE: normalize the vectors to score by cosine similarity.
+func (m MultiVector) MaxSim(d MultiVector) (float64, error) {
+	if m.Len() > 0 && d.Len() > 0 && m.Dims() != d.Dims() {
+		return 0, ErrDimMismatch
+	}
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+	if err := d.Validate(); err != nil {
+		return 0, err
+	}
+
+	if d.Len() == 0 {
+		return 0, nil
+	}
+
+	var score float64
+	for _, q := range m.Vectors {
+		maxSim := math.Inf(-1)
+		for _, v := range d.Vectors {
+			maxSim = math.Max(maxSim, dot(q, v))
+		}
+		score += maxSim
+	}
+	return score, nil
+}
+
+// Normalize returns a copy of the embedding
+// with every vector scaled to unit length.
+func (m MultiVector) Normalize() *MultiVector {
+	vectors := make([][]float64, 0, len(m.Vectors))
+	for _, v := range m.Vectors {
+		floats := make([]float64, len(v))
+		copy(floats, v)
+		if norm := math.Sqrt(dot(v, v)); norm > 0 {
+			for i := range floats {
+				floats[i] /= norm
+			}
+		}
+		vectors = append(vectors, floats)
+	}
+	return &MultiVector{
+		Vectors: vectors,
+	}
+}
+
+// MeanPool returns the mean of the vectors as a single embedding.
+// It returns ErrInvalidMultiVector if the embedding has no vectors.
+func (m MultiVector) MeanPool() (*Embedding, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if m.Len() == 0 {
+		return nil, ErrInvalidMultiVector
+	}
+	mean := make([]float64, m.Dims())
+	for _, v := range m.Vectors {
+		for i, f := range v {
+			mean[i] += f
+		}
+	}
+	for i := range mean {
+		mean[i] /= float64(m.Len())
+	}
+	return &Embedding{
+		Vector: mean,
+	}, nil
+}
+
+// Pool compresses the embedding by the given factor: it returns a copy
+// of the embedding with at most ceil(Len/factor) vectors. The most similar
+// adjacent vectors are merged into their mean, weighted by the number of
+// vectors they pool, until the embedding is small enough.
+func (m MultiVector) Pool(factor int) (*MultiVector, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float64, 0, m.Len())
+	counts := make([]int, 0, m.Len())
+	for _, v := range m.Vectors {
+		floats := make([]float64, len(v))
+		copy(floats, v)
+		vectors = append(vectors, floats)
+		counts = append(counts, 1)
+	}
+	if factor <= 1 {
+		return &MultiVector{Vectors: vectors}, nil
+	}
+
+	target := (m.Len() + factor - 1) / factor
+	for len(vectors) > target {
+		best, bestSim := 0, math.Inf(-1)
+		for i := 0; i < len(vectors)-1; i++ {
+			if sim := cosine(vectors[i], vectors[i+1]); sim > bestSim {
+				best, bestSim = i, sim
+			}
+		}
+
+		a, b := counts[best], counts[best+1]
+		for i := range vectors[best] {
+			vectors[best][i] = (vectors[best][i]*float64(a) + vectors[best+1][i]*float64(b)) / float64(a+b)
+		}
+		counts[best] = a + b
+		vectors = append(vectors[:best+1], vectors[best+2:]...)
+		counts = append(counts[:best+1], counts[best+2:]...)
+	}
+
+	return &MultiVector{
+		Vectors: vectors,
+	}, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The embedding is encoded as the format version followed by
+// uvarint encoded number of vectors and their dimensions,
+// followed by the little-endian float64 vector values.
+func (m MultiVector) MarshalBinary() ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 0, 1+2*binary.MaxVarintLen64+m.Len()*m.Dims()*8)
+	buf = append(buf, multiVectorVersion)
+	buf = binary.AppendUvarint(buf, uint64(m.Len()))  // nolint:gosec
+	buf = binary.AppendUvarint(buf, uint64(m.Dims())) // nolint:gosec
+	for _, v := range m.Vectors {
+		for _, f := range v {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(f))
+		}
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (m *MultiVector) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != multiVectorVersion {
+		return ErrInvalidMultiVector
+	}
+	data = data[1:]
+
+	size, n := binary.Uvarint(data)
+	if n <= 0 || size > math.MaxInt32 {
+		return ErrInvalidMultiVector
+	}
+	data = data[n:]
+
+	dims, n := binary.Uvarint(data)
+	if n <= 0 || dims > math.MaxInt32 {
+		return ErrInvalidMultiVector
+	}
+	data = data[n:]
+
+	switch {
+	case dims == 0:
+		if size != 0 || len(data) != 0 {
+			return ErrInvalidMultiVector
+		}
+	case uint64(len(data))%(dims*8) != 0 || uint64(len(data))/(dims*8) != size:
+		return ErrInvalidMultiVector
+	}
+
+	vectors := make([][]float64, 0, size)
+	for range size {
+		floats := make([]float64, dims)
+		for i := range floats {
+			floats[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[:8]))
+			data = data[8:]
+		}
+		vectors = append(vectors, floats)
+	}
+	m.Vectors = vectors
+
+	return nil
+}
+
+// dot returns the dot product of the vectors.
+func dot(a, b []float64) float64 {
+	var d float64
+	for i := range a {
+		d += a[i] * b[i]
+	}
+	return d
+}
+
+// cosine returns the cosine similarity of the vectors.
+func cosine(a, b []float64) float64 {
+	norm := math.Sqrt(dot(a, a)) * math.Sqrt(dot(b, b))
+	if norm == 0 {
+		return 0
+	}
+	return dot(a, b) / norm
+}
@@ -0,0 +1,173 @@
+package embeddings
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMultiVectorMaxSim(t *testing.T) {
+	t.Parallel()
+
+	q := NewMultiVector([]*Embedding{
+		{Vector: []float64{1, 0}},
+		{Vector: []float64{0, 1}},
+	})
+	d := MultiVector{Vectors: [][]float64{{0.5, 0.1}, {0.2, 0.8}, {-1, -1}}}
+
+	score, err := q.MaxSim(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp := 0.5 + 0.8; math.Abs(score-exp) > 1e-12 {
+		t.Fatalf("expected score %v, got %v", exp, score)
+	}
+
+	if score, err := q.MaxSim(MultiVector{}); err != nil || score != 0 {
+		t.Fatalf("expected zero score, got %v, %v", score, err)
+	}
+	if _, err := q.MaxSim(MultiVector{Vectors: [][]float64{{1, 2, 3}}}); !errors.Is(err, ErrDimMismatch) {
+		t.Fatalf("expected error: %v, got: %v", ErrDimMismatch, err)
+	}
+	if _, err := q.MaxSim(MultiVector{Vectors: [][]float64{{1, 2}, {3}}}); !errors.Is(err, ErrInvalidMultiVector) {
+		t.Fatalf("expected error: %v, got: %v", ErrInvalidMultiVector, err)
+	}
+}
+
+func TestMultiVectorPooling(t *testing.T) {
+	t.Parallel()
+
+	m := MultiVector{Vectors: [][]float64{{1, 0}, {0.9, 0.1}, {0, 1}, {0.1, 0.9}, {-1, 0}}}
+
+	mean, err := m.MeanPool()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp := []float64{0.2, 0.4}; math.Abs(mean.Vector[0]-exp[0]) > 1e-12 || math.Abs(mean.Vector[1]-exp[1]) > 1e-12 {
+		t.Fatalf("expected mean: %v, got: %v", exp, mean.Vector)
+	}
+
+	pooled, err := m.Pool(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := [][]float64{{0.95, 0.05}, {0.05, 0.95}, {-1, 0}}
+	if pooled.Len() != len(exp) {
+		t.Fatalf("expected %d vectors, got %d", len(exp), pooled.Len())
+	}
+	for i := range exp {
+		for j := range exp[i] {
+			if math.Abs(pooled.Vectors[i][j]-exp[i][j]) > 1e-12 {
+				t.Fatalf("expected: %v, got: %v", exp, pooled.Vectors)
+			}
+		}
+	}
+	if m.Vectors[0][0] != 1 || m.Len() != 5 {
+		t.Fatalf("Pool modified the embedding: %v", m)
+	}
+
+	pooled, err = m.Pool(1)
+	if err != nil || !reflect.DeepEqual(pooled.Vectors, m.Vectors) {
+		t.Fatalf("expected unpooled copy, got: %v, %v", pooled, err)
+	}
+
+	if _, err := (MultiVector{}).MeanPool(); !errors.Is(err, ErrInvalidMultiVector) {
+		t.Fatalf("expected error: %v, got: %v", ErrInvalidMultiVector, err)
+	}
+
+	mvs := NewMultiVectors([][][]float64{{{1, 2}, {3, 4}}, {{1, 1}}})
+	means, err := MeanPoolEmbeddings(mvs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(means) != 2 || !reflect.DeepEqual(means[0].Vector, []float64{2, 3}) || !reflect.DeepEqual(means[1].Vector, []float64{1, 1}) {
+		t.Fatalf("unexpected mean-pooled embeddings: %v", means)
+	}
+	if _, err := MeanPoolEmbeddings(NewMultiVectors([][][]float64{{{1, 2}, {3}}})); !errors.Is(err, ErrInvalidMultiVector) {
+		t.Fatalf("expected error: %v, got: %v", ErrInvalidMultiVector, err)
+	}
+
+	norm := MultiVector{Vectors: [][]float64{{3, 4}, {0, 0}}}.Normalize()
+	if exp := [][]float64{{0.6, 0.8}, {0, 0}}; !reflect.DeepEqual(norm.Vectors, exp) {
+		t.Fatalf("expected: %v, got: %v", exp, norm.Vectors)
+	}
+}
+
+func TestMultiVectorSerialization(t *testing.T) {
+	t.Parallel()
+
+	m := &MultiVector{Vectors: [][]float64{{1, -2, 0.5}, {3, 4, 1e-9}}}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	js := new(MultiVector)
+	if err := json.Unmarshal(b, js); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(js, m) {
+		t.Fatalf("expected: %v, got: %v", m, js)
+	}
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bin := new(MultiVector)
+	if err := bin.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(bin, m) {
+		t.Fatalf("expected: %v, got: %v", m, bin)
+	}
+
+	for _, m := range []MultiVector{{Vectors: [][]float64{{1}, {1, 2}}}, {Vectors: [][]float64{{}, {}}}} {
+		if _, err := m.MarshalBinary(); !errors.Is(err, ErrInvalidMultiVector) {
+			t.Fatalf("expected error: %v, got: %v", ErrInvalidMultiVector, err)
+		}
+	}
+
+	data, err = MultiVector{}.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := bin.UnmarshalBinary(data); err != nil || bin.Len() != 0 {
+		t.Fatalf("expected empty embedding, got: %v, %v", bin, err)
+	}
+	for _, data := range [][]byte{nil, {2}, data[:len(data)-1], {multiVectorVersion, 1, 0}} {
+		if err := bin.UnmarshalBinary(data); !errors.Is(err, ErrInvalidMultiVector) {
+			t.Fatalf("expected error: %v, got: %v", ErrInvalidMultiVector, err)
+		}
+	}
+}
+
+func TestMultiVectorEmbeddings(t *testing.T) {
+	t.Parallel()
+
+	tokens := [][][]float64{{{1, 2}}, {{3, 4}, {5, 6}}}
+	mvs := NewMultiVectors(tokens)
+
+	if _, err := PooledEmbeddings(mvs); !errors.Is(err, ErrUnpooled) {
+		t.Fatalf("expected error: %v, got: %v", ErrUnpooled, err)
+	}
+	embs, err := PooledEmbeddings(mvs[:1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(embs) != 1 || !reflect.DeepEqual(embs[0].Vector, []float64{1, 2}) {
+		t.Fatalf("unexpected pooled embeddings: %v", embs)
+	}
+
+	tokenEmbs := TokenEmbeddings(mvs)
+	if len(tokenEmbs) != 2 || len(tokenEmbs[1]) != 2 || !reflect.DeepEqual(tokenEmbs[1][1].Vector, []float64{5, 6}) {
+		t.Fatalf("unexpected token embeddings: %v", tokenEmbs)
+	}
+
+	tokenEmbs[1][1].Vector[0] = 0
+	if tokens[1][1][0] != 5 || mvs[1].Vectors[1][0] != 5 {
+		t.Fatal("token embeddings share memory with the multi-vector")
+	}
+}
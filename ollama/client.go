@@ -1,6 +1,8 @@
 package ollama
 
 import (
+	"sync/atomic"
+
 	"github.com/milosgajdos/go-embeddings"
 	"github.com/milosgajdos/go-embeddings/client"
 )
@@ -13,11 +15,20 @@ const (
 // Client is an OpenAI HTTP API client.
 type Client struct {
 	opts Options
+	// legacy is set when the server does not support
+	// the embed API and the legacy API call succeeded.
+	legacy atomic.Bool
 }
 
 type Options struct {
 	BaseURL    string
 	HTTPClient *client.HTTP
+	// Legacy forces the use of the
+	// legacy embeddings API endpoint.
+	Legacy bool
+	// Concurrency is the maximum number
+	// of concurrent legacy API requests.
+	Concurrency int
 }
 
 // Option is functional option.
@@ -27,8 +38,9 @@ type Option func(*Options)
 // You can override the default options via the client methods.
 func NewClient(opts ...Option) *Client {
 	options := Options{
-		BaseURL:    BaseURL,
-		HTTPClient: client.NewHTTP(),
+		BaseURL:     BaseURL,
+		HTTPClient:  client.NewHTTP(),
+		Concurrency: client.DefaultConcurrency,
 	}
 
 	for _, apply := range opts {
@@ -59,3 +71,18 @@ func WithHTTPClient(httpClient *client.HTTP) Option {
 		o.HTTPClient = httpClient
 	}
 }
+
+// WithLegacy forces the client to use the legacy embeddings API.
+func WithLegacy(legacy bool) Option {
+	return func(o *Options) {
+		o.Legacy = legacy
+	}
+}
+
+// WithConcurrency sets the maximum number
+// of concurrent legacy API requests.
+func WithConcurrency(n int) Option {
+	return func(o *Options) {
+		o.Concurrency = n
+	}
+}
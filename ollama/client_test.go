@@ -17,6 +17,14 @@ func TestClient(t *testing.T) {
 		assert.Equal(t, c.opts.BaseURL, testVal)
 	})
 
+	t.Run("legacy", func(t *testing.T) {
+		c := NewClient()
+		assert.False(t, c.opts.Legacy)
+
+		c = NewClient(WithLegacy(true))
+		assert.True(t, c.opts.Legacy)
+	})
+
 	t.Run("http client", func(t *testing.T) {
 		c := NewClient()
 		assert.NotNil(t, c.opts.HTTPClient)
@@ -4,20 +4,90 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
 	"github.com/milosgajdos/go-embeddings"
+	"github.com/milosgajdos/go-embeddings/client"
 	"github.com/milosgajdos/go-embeddings/request"
 )
 
 // EmbeddingRequest is serialized and sent to the API server.
+// https://github.com/ollama/ollama/blob/main/docs/api.md#generate-embeddings
 type EmbeddingRequest struct {
-	Prompt any    `json:"prompt"`
+	// Input is either a string or a slice of strings.
+	Input any `json:"input,omitempty"`
+	// Prompt is used as Input if Input is not set.
+	// Deprecated: use Input.
+	Prompt any    `json:"prompt,omitempty"`
 	Model  string `json:"model"`
+	// Truncate truncates the end of each input to fit within
+	// the model context length. It defaults to true on the server.
+	Truncate *bool `json:"truncate,omitempty"`
+	// KeepAlive controls how long the model stays loaded
+	// in memory following the request e.g. "5m".
+	KeepAlive string `json:"keep_alive,omitempty"`
+	// Dimensions truncates the output embeddings
+	// to the number of dimensions if the model supports it.
+	Dimensions int `json:"dimensions,omitempty"`
+	// Options are additional model parameters e.g. num_ctx.
+	Options map[string]any `json:"options,omitempty"`
 }
 
-// EmbeddingResponse received from API.
+// inputs returns the request inputs as a slice of strings.
+func (e *EmbeddingRequest) inputs() ([]string, error) {
+	input := e.Input
+	if input == nil {
+		input = e.Prompt
+	}
+	switch v := input.(type) {
+	case string:
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	}
+	return nil, ErrInvalidInput
+}
+
+// validateLegacy returns ErrUnsupportedParam if the request
+// sets parameters which the legacy embeddings API does not support.
+func (e *EmbeddingRequest) validateLegacy() error {
+	if e.Truncate != nil {
+		return fmt.Errorf("%w: truncate requires the embed API", ErrUnsupportedParam)
+	}
+	if e.Dimensions != 0 {
+		return fmt.Errorf("%w: dimensions requires the embed API", ErrUnsupportedParam)
+	}
+	return nil
+}
+
+// EmbedResponse is the embed API response.
+type EmbedResponse struct {
+	Model           string      `json:"model"`
+	Embeddings      [][]float64 `json:"embeddings"`
+	TotalDuration   int64       `json:"total_duration,omitempty"`
+	LoadDuration    int64       `json:"load_duration,omitempty"`
+	PromptEvalCount int         `json:"prompt_eval_count,omitempty"`
+}
+
+// ToEmbeddings converts the API response,
+// into a slice of embeddings and returns it.
+func (e *EmbedResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	embs := make([]*embeddings.Embedding, 0, len(e.Embeddings))
+	for _, vals := range e.Embeddings {
+		floats := make([]float64, len(vals))
+		copy(floats, vals)
+		embs = append(embs, &embeddings.Embedding{
+			Vector: floats,
+		})
+	}
+	return embs, nil
+}
+
+// EmbeddingResponse received from the legacy embeddings API.
 type EmbeddingResponse struct {
 	Embedding []float64 `json:"embedding"`
 }
@@ -32,36 +102,245 @@ func (e *EmbeddingResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
 	}, nil
 }
 
+// legacyRequest is sent to the legacy embeddings API.
+type legacyRequest struct {
+	Prompt    string         `json:"prompt"`
+	Model     string         `json:"model"`
+	KeepAlive string         `json:"keep_alive,omitempty"`
+	Options   map[string]any `json:"options,omitempty"`
+}
+
 // Embed returns embeddings for every object in EmbeddingRequest.
 func (c *Client) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.Embedding, error) {
-	u, err := url.Parse(c.opts.BaseURL + "/embeddings")
+	e, err := c.Embeddings(ctx, embReq)
+	if err != nil {
+		return nil, err
+	}
+	return e.ToEmbeddings()
+}
+
+// Embeddings fetches embeddings for every input in EmbeddingRequest
+// and returns the API response. It uses the /api/embed endpoint
+// and falls back to the legacy /api/embeddings endpoint, which
+// embeds a single prompt per request, if the server does not support it.
+// The legacy API does not support Truncate and Dimensions: requests which
+// set them fail with ErrUnsupportedParam instead of silently ignoring them.
+// The legacy requests are dispatched concurrently, see BatchEmbeddings.
+func (c *Client) Embeddings(ctx context.Context, embReq *EmbeddingRequest) (*EmbedResponse, error) {
+	inputs, err := embReq.inputs()
 	if err != nil {
 		return nil, err
 	}
 
-	var body = &bytes.Buffer{}
-	enc := json.NewEncoder(body)
-	enc.SetEscapeHTML(false)
-	if err := enc.Encode(embReq); err != nil {
+	e, err := c.embed(ctx, embReq, inputs)
+	if !errors.Is(err, ErrEndpointNotFound) {
+		return e, err
+	}
+
+	if err := embReq.validateLegacy(); err != nil {
+		return nil, err
+	}
+
+	b := c.legacyBatch(ctx, embReq, inputs)
+	if err := b.Err(); err != nil {
+		return nil, err
+	}
+
+	return &EmbedResponse{
+		Model:      b.Model,
+		Embeddings: b.Embeddings,
+	}, nil
+}
+
+// BatchResponse is the batch embeddings response.
+// Embeddings and Errors are in the order of inputs.
+type BatchResponse struct {
+	Model string
+	// Embeddings contains nil for failed inputs.
+	Embeddings [][]float64
+	// Errors contains nil for embedded inputs.
+	Errors []error
+	// PromptEvalCount is only reported by the embed API.
+	PromptEvalCount int
+}
+
+// Err returns the per-input errors joined
+// into a single error or nil if there are none.
+func (b *BatchResponse) Err() error {
+	return client.JoinErrors(b.Errors)
+}
+
+// ToEmbeddings converts the API response,
+// into a slice of embeddings and returns it.
+// It returns error if any of the inputs failed.
+func (b *BatchResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	if err := b.Err(); err != nil {
+		return nil, err
+	}
+	e := &EmbedResponse{Embeddings: b.Embeddings}
+	return e.ToEmbeddings()
+}
+
+// BatchEmbeddings fetches embeddings for every input in EmbeddingRequest.
+// The embed API embeds all the inputs in a single request. When the server
+// only supports the legacy embeddings API every input is sent in a separate
+// request dispatched concurrently by at most Concurrency workers; the requests
+// are rate limited by the HTTP client Limiter. Failed inputs do not fail
+// the batch: their errors are returned in BatchResponse. The legacy requests
+// which set Truncate or Dimensions fail with ErrUnsupportedParam.
+func (c *Client) BatchEmbeddings(ctx context.Context, embReq *EmbeddingRequest) (*BatchResponse, error) {
+	inputs, err := embReq.inputs()
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := c.embed(ctx, embReq, inputs)
+	switch {
+	case err == nil:
+		return &BatchResponse{
+			Model:           e.Model,
+			Embeddings:      e.Embeddings,
+			Errors:          make([]error, len(e.Embeddings)),
+			PromptEvalCount: e.PromptEvalCount,
+		}, nil
+	case !errors.Is(err, ErrEndpointNotFound):
+		return nil, err
+	}
+
+	if err := embReq.validateLegacy(); err != nil {
+		return nil, err
+	}
+
+	return c.legacyBatch(ctx, embReq, inputs), nil
+}
+
+// embed embeds the inputs using the embed API.
+// It returns ErrEndpointNotFound if the server does not support it.
+func (c *Client) embed(ctx context.Context, embReq *EmbeddingRequest, inputs []string) (*EmbedResponse, error) {
+	if c.opts.Legacy || c.legacy.Load() {
+		return nil, ErrEndpointNotFound
+	}
+
+	req := *embReq
+	req.Input, req.Prompt = inputs, nil
+
+	e := new(EmbedResponse)
+	if err := c.post(ctx, "/embed", &req, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// legacyBatch embeds the inputs concurrently using the legacy embeddings API.
+func (c *Client) legacyBatch(ctx context.Context, embReq *EmbeddingRequest, inputs []string) *BatchResponse {
+	resps, errs := client.FanOut(ctx, c.opts.Concurrency, inputs, func(ctx context.Context, input string) (*EmbeddingResponse, error) {
+		return c.legacyEmbed(ctx, embReq, input)
+	})
+
+	b := &BatchResponse{
+		Model:      embReq.Model,
+		Embeddings: make([][]float64, len(inputs)),
+		Errors:     errs,
+	}
+	for i, resp := range resps {
+		if resp != nil {
+			b.Embeddings[i] = resp.Embedding
+		}
+	}
+	return b
+}
+
+// legacyEmbed embeds a single input using the legacy embeddings API.
+// The client keeps using the legacy API once it has succeeded.
+func (c *Client) legacyEmbed(ctx context.Context, embReq *EmbeddingRequest, input string) (*EmbeddingResponse, error) {
+	req := &legacyRequest{
+		Prompt:    input,
+		Model:     embReq.Model,
+		KeepAlive: embReq.KeepAlive,
+		Options:   embReq.Options,
+	}
+	e := new(EmbeddingResponse)
+	if err := c.post(ctx, "/embeddings", req, e); err != nil {
 		return nil, err
 	}
+	c.legacy.Store(true)
+	return e, nil
+}
+
+// post sends the payload to the given API endpoint
+// and decodes the API response into resp.
+func (c *Client) post(ctx context.Context, endpoint string, payload, resp any) error {
+	return c.send(ctx, http.MethodPost, endpoint, payload, resp)
+}
+
+// send sends the payload to the given API endpoint using the given
+// HTTP method and decodes the API response into resp.
+// The payload is not sent and the response is not decoded if they're nil.
+func (c *Client) send(ctx context.Context, method, endpoint string, payload, resp any) error {
+	req, err := c.newRequest(ctx, method, endpoint, payload)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if resp == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(resp)
+}
 
-	options := []request.Option{}
-	req, err := request.NewHTTP(ctx, http.MethodPost, u.String(), body, options...)
+// newRequest creates a new API request with the JSON encoded payload.
+func (c *Client) newRequest(ctx context.Context, method, endpoint string, payload any) (*http.Request, error) {
+	u, err := url.Parse(c.opts.BaseURL + endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := request.Do[APIError](c.opts.HTTPClient, req)
+	var body = &bytes.Buffer{}
+	if payload != nil {
+		enc := json.NewEncoder(body)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	return request.NewHTTP(ctx, method, u.String(), body)
+}
+
+// do sends the HTTP request and returns the response.
+// Unlike request.Do it returns ErrEndpointNotFound
+// if the server does not recognize the API endpoint.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.opts.HTTPClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
 	defer resp.Body.Close()
 
-	e := new(EmbeddingResponse)
-	if err := json.NewDecoder(resp.Body).Decode(e); err != nil {
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
 		return nil, err
 	}
 
-	return e.ToEmbeddings()
+	var apiErr APIError
+	if err := json.Unmarshal(data, &apiErr); err != nil || apiErr.ErrorMessage == "" {
+		// NOTE: unknown endpoints return plain text 404 response
+		// while the known ones return JSON encoded API errors.
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, ErrEndpointNotFound
+		}
+		apiErr.ErrorMessage = http.StatusText(resp.StatusCode)
+	}
+
+	return nil, apiErr
 }
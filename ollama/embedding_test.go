@@ -0,0 +1,248 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("embed", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, r.URL.Path, "/embed")
+
+			req := map[string]any{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, req["input"], []any{"foo", "bar"})
+			assert.Equal(t, req["truncate"], false)
+			assert.Equal(t, req["keep_alive"], "5m")
+			assert.Equal(t, req["options"], map[string]any{"num_ctx": float64(2048)})
+			assert.NotContains(t, req, "prompt")
+
+			_, _ = w.Write([]byte(`{"model":"foo","embeddings":[[1.0],[2.0]],"prompt_eval_count":4}`))
+		}))
+		defer srv.Close()
+
+		truncate := false
+		c := NewClient(WithBaseURL(srv.URL))
+		e, err := c.Embeddings(context.Background(), &EmbeddingRequest{
+			Input:     []string{"foo", "bar"},
+			Model:     "foo",
+			Truncate:  &truncate,
+			KeepAlive: "5m",
+			Options:   map[string]any{"num_ctx": 2048},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, e.Embeddings, [][]float64{{1.0}, {2.0}})
+		assert.Equal(t, e.PromptEvalCount, 4)
+	})
+
+	t.Run("legacy fallback", func(t *testing.T) {
+		t.Parallel()
+
+		var embedCalls, legacyCalls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/embed":
+				embedCalls.Add(1)
+				http.NotFound(w, r)
+			case "/embeddings":
+				legacyCalls.Add(1)
+				req := new(legacyRequest)
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+				if req.Prompt == "foo" {
+					_, _ = w.Write([]byte(`{"embedding":[1.0]}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"embedding":[2.0]}`))
+			}
+		}))
+		defer srv.Close()
+
+		c := NewClient(WithBaseURL(srv.URL))
+		for i := 0; i < 2; i++ {
+			embs, err := c.Embed(context.Background(), &EmbeddingRequest{
+				Input: []string{"foo", "bar"},
+				Model: "foo",
+			})
+			assert.NoError(t, err)
+			assert.Len(t, embs, 2)
+			assert.Equal(t, embs[0].Vector, []float64{1.0})
+			assert.Equal(t, embs[1].Vector, []float64{2.0})
+		}
+		assert.Equal(t, embedCalls.Load(), int32(1))
+		assert.Equal(t, legacyCalls.Load(), int32(4))
+
+		truncate := false
+		_, err := c.Embed(context.Background(), &EmbeddingRequest{
+			Input:    "foo",
+			Model:    "foo",
+			Truncate: &truncate,
+		})
+		assert.ErrorIs(t, err, ErrUnsupportedParam)
+		_, err = c.BatchEmbeddings(context.Background(), &EmbeddingRequest{
+			Input:      "foo",
+			Model:      "foo",
+			Dimensions: 4,
+		})
+		assert.ErrorIs(t, err, ErrUnsupportedParam)
+		assert.Equal(t, legacyCalls.Load(), int32(4))
+	})
+
+	t.Run("model not found", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"model \"foo\" not found"}`))
+		}))
+		defer srv.Close()
+
+		c := NewClient(WithBaseURL(srv.URL))
+		_, err := c.Embed(context.Background(), &EmbeddingRequest{
+			Prompt: "foo",
+			Model:  "foo",
+		})
+		assert.ErrorAs(t, err, &APIError{})
+	})
+
+	t.Run("empty error", func(t *testing.T) {
+		t.Parallel()
+
+		for _, body := range []string{`{}`, `upstream connect error`} {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(body))
+			}))
+			defer srv.Close()
+
+			c := NewClient(WithBaseURL(srv.URL))
+			_, err := c.Embed(context.Background(), &EmbeddingRequest{
+				Input: "foo",
+				Model: "foo",
+			})
+			assert.ErrorIs(t, err, APIError{ErrorMessage: http.StatusText(http.StatusInternalServerError)}, body)
+		}
+	})
+
+	t.Run("endpoint not found", func(t *testing.T) {
+		t.Parallel()
+
+		var ready atomic.Bool
+		var embedCalls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !ready.Load() {
+				http.NotFound(w, r)
+				return
+			}
+			assert.Equal(t, r.URL.Path, "/embed")
+			embedCalls.Add(1)
+			_, _ = w.Write([]byte(`{"model":"foo","embeddings":[[1.0]]}`))
+		}))
+		defer srv.Close()
+
+		c := NewClient(WithBaseURL(srv.URL))
+		req := &EmbeddingRequest{Input: "foo", Model: "foo"}
+		_, err := c.Embed(context.Background(), req)
+		assert.ErrorIs(t, err, ErrEndpointNotFound)
+
+		ready.Store(true)
+		embs, err := c.Embed(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, embs[0].Vector, []float64{1.0})
+		assert.Equal(t, embedCalls.Load(), int32(1))
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		c := NewClient()
+		_, err := c.Embed(context.Background(), &EmbeddingRequest{
+			Input: 1,
+			Model: "foo",
+		})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestBatchEmbeddings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("embed", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, r.URL.Path, "/embed")
+			_, _ = w.Write([]byte(`{"model":"foo","embeddings":[[1.0],[2.0]],"prompt_eval_count":4}`))
+		}))
+		defer srv.Close()
+
+		c := NewClient(WithBaseURL(srv.URL))
+		b, err := c.BatchEmbeddings(context.Background(), &EmbeddingRequest{
+			Input: []string{"foo", "bar"},
+			Model: "foo",
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, b.Err())
+		assert.Equal(t, b.Embeddings, [][]float64{{1.0}, {2.0}})
+		assert.Equal(t, b.PromptEvalCount, 4)
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		t.Parallel()
+
+		var running, peak atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, r.URL.Path, "/embeddings")
+
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			req := new(legacyRequest)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			if req.Prompt == "bad" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"bad prompt"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"embedding":[` + strconv.Itoa(len(req.Prompt)) + `]}`))
+		}))
+		defer srv.Close()
+
+		c := NewClient(WithBaseURL(srv.URL), WithLegacy(true), WithConcurrency(2))
+		inputs := []string{"a", "bbb", "bad", "cc", "dddd"}
+		b, err := c.BatchEmbeddings(context.Background(), &EmbeddingRequest{
+			Input: inputs,
+			Model: "foo",
+		})
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, peak.Load(), int32(2))
+		assert.Equal(t, b.Embeddings, [][]float64{{1}, {3}, nil, {2}, {4}})
+		assert.Equal(t, b.Errors[2], APIError{ErrorMessage: "bad prompt"})
+		for _, i := range []int{0, 1, 3, 4} {
+			assert.NoError(t, b.Errors[i])
+		}
+		assert.ErrorIs(t, b.Err(), APIError{ErrorMessage: "bad prompt"})
+
+		_, err = b.ToEmbeddings()
+		assert.Error(t, err)
+
+		_, err = c.Embed(context.Background(), &EmbeddingRequest{Input: inputs, Model: "foo"})
+		assert.ErrorIs(t, err, APIError{ErrorMessage: "bad prompt"})
+	})
+}
@@ -1,6 +1,18 @@
 package ollama
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrInvalidInput is returned when the request input is neither a string nor a slice of strings.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrEndpointNotFound is returned when the API server does not support the requested endpoint.
+	ErrEndpointNotFound = errors.New("endpoint not found")
+	// ErrUnsupportedParam is returned when the request parameter is not supported by the legacy embeddings API.
+	ErrUnsupportedParam = errors.New("unsupported parameter")
+)
 
 // APIError is Ollama API error.
 type APIError struct {
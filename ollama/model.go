@@ -0,0 +1,173 @@
+package ollama
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// ModelDetails describes the model.
+type ModelDetails struct {
+	ParentModel       string   `json:"parent_model"`
+	Format            string   `json:"format"`
+	Family            string   `json:"family"`
+	Families          []string `json:"families"`
+	ParameterSize     string   `json:"parameter_size"`
+	QuantizationLevel string   `json:"quantization_level"`
+}
+
+// Model is a locally available model.
+type Model struct {
+	Name       string       `json:"name"`
+	Model      string       `json:"model"`
+	ModifiedAt time.Time    `json:"modified_at"`
+	Size       int64        `json:"size"`
+	Digest     string       `json:"digest"`
+	Details    ModelDetails `json:"details"`
+}
+
+// ListModelsResponse is the list models API response.
+type ListModelsResponse struct {
+	Models []Model `json:"models"`
+}
+
+// ShowModelRequest is sent to the show model API.
+type ShowModelRequest struct {
+	Model   string `json:"model"`
+	Verbose bool   `json:"verbose,omitempty"`
+}
+
+// ShowModelResponse is the show model API response.
+type ShowModelResponse struct {
+	License      string         `json:"license,omitempty"`
+	Modelfile    string         `json:"modelfile,omitempty"`
+	Parameters   string         `json:"parameters,omitempty"`
+	Template     string         `json:"template,omitempty"`
+	Details      ModelDetails   `json:"details"`
+	ModelInfo    map[string]any `json:"model_info,omitempty"`
+	Capabilities []string       `json:"capabilities,omitempty"`
+	ModifiedAt   time.Time      `json:"modified_at"`
+}
+
+// Architecture returns the model architecture e.g. bert.
+func (s *ShowModelResponse) Architecture() string {
+	arch, _ := s.ModelInfo["general.architecture"].(string)
+	return arch
+}
+
+// EmbeddingLength returns the length of the model embeddings.
+// It returns 0 if the model does not report it.
+func (s *ShowModelResponse) EmbeddingLength() int {
+	return s.archInfo("embedding_length")
+}
+
+// ContextLength returns the model context length in tokens.
+// It returns 0 if the model does not report it.
+func (s *ShowModelResponse) ContextLength() int {
+	return s.archInfo("context_length")
+}
+
+// archInfo returns architecture specific integer model info.
+func (s *ShowModelResponse) archInfo(key string) int {
+	// NOTE: JSON numbers are decoded as float64
+	val, _ := s.ModelInfo[s.Architecture()+"."+key].(float64)
+	return int(val)
+}
+
+// PullModelRequest is sent to the pull model API.
+type PullModelRequest struct {
+	Model    string `json:"model"`
+	Insecure bool   `json:"insecure,omitempty"`
+}
+
+// PullProgress is a model pull progress event.
+type PullProgress struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+}
+
+// PullProgressFunc is called for every model pull progress event.
+// If it returns error the model pull is aborted.
+type PullProgressFunc func(PullProgress) error
+
+// DeleteModelRequest is sent to the delete model API.
+type DeleteModelRequest struct {
+	Model string `json:"model"`
+}
+
+// ListModels returns the models available locally on the server.
+func (c *Client) ListModels(ctx context.Context) ([]Model, error) {
+	resp := new(ListModelsResponse)
+	if err := c.send(ctx, http.MethodGet, "/tags", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.Models, nil
+}
+
+// ShowModel returns the information about the model.
+func (c *Client) ShowModel(ctx context.Context, showReq *ShowModelRequest) (*ShowModelResponse, error) {
+	resp := new(ShowModelResponse)
+	if err := c.post(ctx, "/show", showReq, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PullModel downloads the model from the model registry.
+// It streams the pull progress events through fn if it's not nil.
+// It returns when the model has been pulled or when the pull fails.
+func (c *Client) PullModel(ctx context.Context, pullReq *PullModelRequest, fn PullProgressFunc) error {
+	payload := struct {
+		*PullModelRequest
+		Stream bool `json:"stream"`
+	}{
+		PullModelRequest: pullReq,
+		Stream:           true,
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/pull", payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var event struct {
+			PullProgress
+			APIError
+		}
+		if err := json.Unmarshal(line, &event); err != nil {
+			return err
+		}
+		if event.ErrorMessage != "" {
+			return event.APIError
+		}
+		if fn != nil {
+			if err := fn(event.PullProgress); err != nil {
+				return err
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// DeleteModel deletes the model from the server.
+func (c *Client) DeleteModel(ctx context.Context, delReq *DeleteModelRequest) error {
+	return c.send(ctx, http.MethodDelete, "/delete", delReq, nil)
+}
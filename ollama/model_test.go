@@ -0,0 +1,91 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModels(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tags":
+			_, _ = w.Write([]byte(`{"models":[{"name":"nomic-embed-text:latest","model":"nomic-embed-text:latest","size":274302450,"details":{"family":"nomic-bert"}}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/show":
+			_, _ = w.Write([]byte(`{"details":{"family":"nomic-bert"},"model_info":{"general.architecture":"nomic-bert","nomic-bert.embedding_length":768,"nomic-bert.context_length":2048}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/pull":
+			req := map[string]any{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, req["stream"], true)
+			if req["model"] == "missing" {
+				_, _ = w.Write([]byte(`{"status":"pulling manifest"}` + "\n" + `{"error":"pull model manifest: file does not exist"}` + "\n"))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"pulling manifest"}` + "\n" +
+				`{"status":"downloading","digest":"sha256:foo","total":10,"completed":5}` + "\n" +
+				`{"status":"success"}` + "\n"))
+		case r.Method == http.MethodDelete && r.URL.Path == "/delete":
+			req := new(DeleteModelRequest)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			if req.Model != "nomic-embed-text" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":"model not found"}`))
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	t.Run("list", func(t *testing.T) {
+		models, err := c.ListModels(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, models, 1)
+		assert.Equal(t, models[0].Name, "nomic-embed-text:latest")
+		assert.Equal(t, models[0].Details.Family, "nomic-bert")
+	})
+
+	t.Run("show", func(t *testing.T) {
+		m, err := c.ShowModel(ctx, &ShowModelRequest{Model: "nomic-embed-text"})
+		assert.NoError(t, err)
+		assert.Equal(t, m.Architecture(), "nomic-bert")
+		assert.Equal(t, m.EmbeddingLength(), 768)
+		assert.Equal(t, m.ContextLength(), 2048)
+	})
+
+	t.Run("pull", func(t *testing.T) {
+		var events []PullProgress
+		err := c.PullModel(ctx, &PullModelRequest{Model: "nomic-embed-text"}, func(p PullProgress) error {
+			events = append(events, p)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Len(t, events, 3)
+		assert.Equal(t, events[1].Completed, int64(5))
+		assert.Equal(t, events[2].Status, "success")
+
+		err = c.PullModel(ctx, &PullModelRequest{Model: "missing"}, nil)
+		assert.ErrorAs(t, err, &APIError{})
+
+		errAbort := errors.New("abort")
+		err = c.PullModel(ctx, &PullModelRequest{Model: "nomic-embed-text"}, func(PullProgress) error {
+			return errAbort
+		})
+		assert.ErrorIs(t, err, errAbort)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assert.NoError(t, c.DeleteModel(ctx, &DeleteModelRequest{Model: "nomic-embed-text"}))
+		assert.ErrorAs(t, c.DeleteModel(ctx, &DeleteModelRequest{Model: "foo"}), &APIError{})
+	})
+}
@@ -85,7 +85,7 @@ func toEmbeddingResp[T any](resp io.Reader) (*EmbeddingResponse, error) {
 	case *EmbeddingResponseGen[embeddings.Base64]:
 		embData := make([]Data, 0, len(e.Data))
 		for _, d := range e.Data {
-			emb, err := d.Embedding.Decode()
+			emb, err := d.Embedding.DecodeFloat32()
 			if err != nil {
 				return nil, err
 			}
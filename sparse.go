@@ -0,0 +1,241 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"sort"
+)
+
+const (
+	// sparseVersion is the sparse embedding binary encoding version.
+	sparseVersion byte = 1
+)
+
+var (
+	// ErrInvalidSparse is returned when the sparse embedding is malformed.
+	ErrInvalidSparse = errors.New("invalid sparse embedding")
+)
+
+// SparseEmbedder fetches sparse embeddings.
+type SparseEmbedder[T any] interface {
+	// SparseEmbed fetches sparse embeddings and returns them.
+	SparseEmbed(context.Context, T) ([]*SparseEmbedding, error)
+}
+
+// SparseEmbedding is sparse vector embedding.
+// It stores the non-zero values of the vector and their
+// indices, e.g. vocabulary term IDs, in ascending index order.
+type SparseEmbedding struct {
+	Indices []int     `json:"indices"`
+	Values  []float64 `json:"values"`
+	// Dims is the number of the vector dimensions
+	// e.g. the vocabulary size; 0 if it's unknown.
+	Dims int `json:"dims,omitempty"`
+}
+
+// NewSparseEmbedding creates a new sparse embedding
+// from the index to value map and returns it.
+// Zero values are omitted.
+func NewSparseEmbedding(vals map[int]float64) *SparseEmbedding {
+	indices := make([]int, 0, len(vals))
+	for i, v := range vals {
+		if v != 0 {
+			indices = append(indices, i)
+		}
+	}
+	sort.Ints(indices)
+
+	values := make([]float64, 0, len(indices))
+	for _, i := range indices {
+		values = append(values, vals[i])
+	}
+
+	return &SparseEmbedding{
+		Indices: indices,
+		Values:  values,
+	}
+}
+
+// Len returns the number of the non-zero values.
+func (s SparseEmbedding) Len() int {
+	return len(s.Indices)
+}
+
+// Map returns the embedding as an index to value map.
+func (s SparseEmbedding) Map() map[int]float64 {
+	vals := make(map[int]float64, len(s.Indices))
+	for i, idx := range s.Indices {
+		vals[idx] = s.Values[i]
+	}
+	return vals
+}
+
+// Dense returns the embedding as a dense embedding with dims dimensions.
+// It returns ErrDimMismatch if any index does not fit into dims.
+func (s SparseEmbedding) Dense(dims int) (*Embedding, error) {
+	floats := make([]float64, dims)
+	for i, idx := range s.Indices {
+		if idx >= dims {
+			return nil, ErrDimMismatch
+		}
+		floats[idx] = s.Values[i]
+	}
+	return &Embedding{
+		Vector: floats,
+	}, nil
+}
+
+// Dot returns the dot product of the embeddings.
+func (s SparseEmbedding) Dot(o SparseEmbedding) float64 {
+	var dot float64
+	for i, j := 0, 0; i < len(s.Indices) && j < len(o.Indices); {
+		switch {
+		case s.Indices[i] < o.Indices[j]:
+			i++
+		case s.Indices[i] > o.Indices[j]:
+			j++
+		default:
+			dot += s.Values[i] * o.Values[j]
+			i++
+			j++
+		}
+	}
+	return dot
+}
+
+// TopK returns a new embedding pruned to the k values
+// with the highest magnitude. The pruned embedding keeps
+// the ascending index order. If k is not smaller than
+// the number of values, a copy of the embedding is returned.
+func (s SparseEmbedding) TopK(k int) *SparseEmbedding {
+	order := make([]int, len(s.Indices))
+	for i := range order {
+		order[i] = i
+	}
+	if k < len(order) {
+		sort.SliceStable(order, func(a, b int) bool {
+			return math.Abs(s.Values[order[a]]) > math.Abs(s.Values[order[b]])
+		})
+		order = order[:max(k, 0)]
+		sort.Ints(order)
+	}
+
+	pruned := &SparseEmbedding{
+		Indices: make([]int, 0, len(order)),
+		Values:  make([]float64, 0, len(order)),
+		Dims:    s.Dims,
+	}
+	for _, i := range order {
+		pruned.Indices = append(pruned.Indices, s.Indices[i])
+		pruned.Values = append(pruned.Values, s.Values[i])
+	}
+	return pruned
+}
+
+// Validate returns ErrInvalidSparse if the indices and values
+// lengths differ or the indices are not unique, non-negative,
+// in ascending order and within Dims if it's set.
+func (s SparseEmbedding) Validate() error {
+	if len(s.Indices) != len(s.Values) {
+		return ErrInvalidSparse
+	}
+	for i, idx := range s.Indices {
+		if idx < 0 || (i > 0 && idx <= s.Indices[i-1]) {
+			return ErrInvalidSparse
+		}
+		if s.Dims > 0 && idx >= s.Dims {
+			return ErrInvalidSparse
+		}
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The embedding is encoded as the format version followed by
+// uvarint encoded dims, number of values and index deltas,
+// followed by the little-endian float64 values.
+func (s SparseEmbedding) MarshalBinary() ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 0, 1+2*binary.MaxVarintLen64+len(s.Indices)*(binary.MaxVarintLen64+8))
+	buf = append(buf, sparseVersion)
+	buf = binary.AppendUvarint(buf, uint64(s.Dims))         // nolint:gosec
+	buf = binary.AppendUvarint(buf, uint64(len(s.Indices))) // nolint:gosec
+
+	prev := 0
+	for _, idx := range s.Indices {
+		buf = binary.AppendUvarint(buf, uint64(idx-prev)) // nolint:gosec
+		prev = idx
+	}
+	for _, v := range s.Values {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *SparseEmbedding) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != sparseVersion {
+		return ErrInvalidSparse
+	}
+	data = data[1:]
+
+	uvarint := func() (int, error) {
+		v, n := binary.Uvarint(data)
+		if n <= 0 || v > math.MaxInt32 {
+			return 0, ErrInvalidSparse
+		}
+		data = data[n:]
+		return int(v), nil
+	}
+
+	dims, err := uvarint()
+	if err != nil {
+		return err
+	}
+	size, err := uvarint()
+	if err != nil {
+		return err
+	}
+	// NOTE: every index takes at least one byte
+	if len(data) < size*9 {
+		return ErrInvalidSparse
+	}
+
+	indices := make([]int, 0, size)
+	prev := 0
+	for range size {
+		delta, err := uvarint()
+		if err != nil {
+			return err
+		}
+		prev += delta
+		indices = append(indices, prev)
+	}
+
+	if len(data) != size*8 {
+		return ErrInvalidSparse
+	}
+	values := make([]float64, 0, size)
+	for i := range size {
+		bits := binary.LittleEndian.Uint64(data[i*8 : (i+1)*8])
+		values = append(values, math.Float64frombits(bits))
+	}
+
+	decoded := SparseEmbedding{
+		Indices: indices,
+		Values:  values,
+		Dims:    dims,
+	}
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+	*s = decoded
+
+	return nil
+}
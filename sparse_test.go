@@ -0,0 +1,141 @@
+package embeddings
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSparseEmbedding(t *testing.T) {
+	t.Parallel()
+
+	s := NewSparseEmbedding(map[int]float64{42: 0.5, 3: 1.5, 7: 0, 100: -2})
+	if exp := []int{3, 42, 100}; !reflect.DeepEqual(s.Indices, exp) {
+		t.Fatalf("expected indices: %v, got: %v", exp, s.Indices)
+	}
+	if exp := []float64{1.5, 0.5, -2}; !reflect.DeepEqual(s.Values, exp) {
+		t.Fatalf("expected values: %v, got: %v", exp, s.Values)
+	}
+	if exp := map[int]float64{3: 1.5, 42: 0.5, 100: -2}; !reflect.DeepEqual(s.Map(), exp) {
+		t.Fatalf("expected map: %v, got: %v", exp, s.Map())
+	}
+
+	o := NewSparseEmbedding(map[int]float64{1: 10, 3: 2, 100: 0.5, 200: 1})
+	if dot := s.Dot(*o); dot != 2 {
+		t.Fatalf("expected dot product 2, got %v", dot)
+	}
+	if dot := o.Dot(*s); dot != 2 {
+		t.Fatalf("expected dot product 2, got %v", dot)
+	}
+
+	d, err := s.Dense(101)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Vector[3] != 1.5 || d.Vector[100] != -2 || len(d.Vector) != 101 {
+		t.Fatalf("unexpected dense vector: %v", d.Vector)
+	}
+	if _, err := s.Dense(100); !errors.Is(err, ErrDimMismatch) {
+		t.Fatalf("expected error: %v, got: %v", ErrDimMismatch, err)
+	}
+}
+
+func TestSparseTopK(t *testing.T) {
+	t.Parallel()
+
+	s := &SparseEmbedding{
+		Indices: []int{1, 5, 9, 12},
+		Values:  []float64{0.1, -3, 2, 0.5},
+		Dims:    20,
+	}
+
+	testCases := []struct {
+		k       int
+		indices []int
+		values  []float64
+	}{
+		{k: 2, indices: []int{5, 9}, values: []float64{-3, 2}},
+		{k: 3, indices: []int{5, 9, 12}, values: []float64{-3, 2, 0.5}},
+		{k: 10, indices: []int{1, 5, 9, 12}, values: []float64{0.1, -3, 2, 0.5}},
+		{k: 0, indices: []int{}, values: []float64{}},
+	}
+
+	for _, tc := range testCases {
+		pruned := s.TopK(tc.k)
+		if !reflect.DeepEqual(pruned.Indices, tc.indices) || !reflect.DeepEqual(pruned.Values, tc.values) {
+			t.Fatalf("k=%d: expected %v %v, got %v %v", tc.k, tc.indices, tc.values, pruned.Indices, pruned.Values)
+		}
+		if pruned.Dims != s.Dims {
+			t.Fatalf("k=%d: expected %d dims, got %d", tc.k, s.Dims, pruned.Dims)
+		}
+	}
+
+	if s.Values[1] != -3 || s.Indices[1] != 5 {
+		t.Fatalf("TopK modified the embedding: %v", s)
+	}
+}
+
+func TestSparseSerialization(t *testing.T) {
+	t.Parallel()
+
+	s := &SparseEmbedding{
+		Indices: []int{0, 3, 300, 30522},
+		Values:  []float64{0.25, 1.5, -2, 1e-9},
+		Dims:    30523,
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp := `{"indices":[0,3,300,30522],"values":[0.25,1.5,-2,1e-9],"dims":30523}`; string(b) != exp {
+		t.Fatalf("expected: %s, got: %s", exp, b)
+	}
+	js := new(SparseEmbedding)
+	if err := json.Unmarshal(b, js); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(js, s) {
+		t.Fatalf("expected: %v, got: %v", s, js)
+	}
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bin := new(SparseEmbedding)
+	if err := bin.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(bin, s) {
+		t.Fatalf("expected: %v, got: %v", s, bin)
+	}
+
+	empty, err := (&SparseEmbedding{}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := bin.UnmarshalBinary(empty); err != nil || bin.Len() != 0 {
+		t.Fatalf("unexpected empty decoding: %v, %v", bin, err)
+	}
+
+	invalid := []*SparseEmbedding{
+		{Indices: []int{1}, Values: []float64{}},
+		{Indices: []int{3, 1}, Values: []float64{1, 2}},
+		{Indices: []int{1, 1}, Values: []float64{1, 2}},
+		{Indices: []int{-1}, Values: []float64{1}},
+		{Indices: []int{5}, Values: []float64{1}, Dims: 5},
+	}
+	for _, s := range invalid {
+		if _, err := s.MarshalBinary(); !errors.Is(err, ErrInvalidSparse) {
+			t.Fatalf("expected error: %v, got: %v", ErrInvalidSparse, err)
+		}
+	}
+
+	for _, data := range [][]byte{nil, {2}, data[:len(data)-1], append(data, 0)} {
+		if err := bin.UnmarshalBinary(data); !errors.Is(err, ErrInvalidSparse) {
+			t.Fatalf("expected error: %v, got: %v", ErrInvalidSparse, err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package tei
+
+import (
+	"context"
+
+	"github.com/milosgajdos/go-embeddings"
+)
+
+// EmbeddingRequest is sent to the embed API endpoints.
+// https://huggingface.github.io/text-embeddings-inference/
+type EmbeddingRequest struct {
+	// Inputs is either a string or a slice of strings.
+	Inputs any `json:"inputs"`
+	// Normalize the embeddings. It defaults to true on the server.
+	// NOTE: only supported by the embed endpoint.
+	Normalize *bool `json:"normalize,omitempty"`
+	// Truncate the inputs that exceed the model maximum input length.
+	Truncate            bool                `json:"truncate,omitempty"`
+	TruncationDirection TruncationDirection `json:"truncation_direction,omitempty"`
+	// PromptName is the name of the prompt configured
+	// by the model which is prepended to the inputs.
+	PromptName string `json:"prompt_name,omitempty"`
+}
+
+// EmbeddingResponse is the embed API response.
+type EmbeddingResponse [][]float64
+
+// ToEmbeddings converts the API response,
+// into a slice of embeddings and returns it.
+func (e EmbeddingResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	embs := make([]*embeddings.Embedding, 0, len(e))
+	for _, vals := range e {
+		floats := make([]float64, len(vals))
+		copy(floats, vals)
+		embs = append(embs, &embeddings.Embedding{
+			Vector: floats,
+		})
+	}
+	return embs, nil
+}
+
+// SparseValue is a non-zero sparse embedding value.
+type SparseValue struct {
+	Index int     `json:"index"`
+	Value float64 `json:"value"`
+}
+
+// SparseEmbeddingResponse is the sparse embed API response.
+// It contains the non-zero values of every input embedding.
+type SparseEmbeddingResponse [][]SparseValue
+
+// ToSparseEmbeddings converts the API response,
+// into a slice of sparse embeddings and returns it.
+func (e SparseEmbeddingResponse) ToSparseEmbeddings() ([]*embeddings.SparseEmbedding, error) {
+	embs := make([]*embeddings.SparseEmbedding, 0, len(e))
+	for _, vals := range e {
+		m := make(map[int]float64, len(vals))
+		for _, v := range vals {
+			m[v.Index] = v.Value
+		}
+		embs = append(embs, embeddings.NewSparseEmbedding(m))
+	}
+	return embs, nil
+}
+
+// TokenEmbeddingResponse is the embed all API response.
+// It contains the embeddings of every token of every input.
+type TokenEmbeddingResponse [][][]float64
+
+// ToEmbeddings converts the API response, into
+// a slice of token embeddings for every input.
+func (e TokenEmbeddingResponse) ToEmbeddings() ([][]*embeddings.Embedding, error) {
+	embs := make([][]*embeddings.Embedding, 0, len(e))
+	for _, tokens := range e {
+		tokenEmbs, err := EmbeddingResponse(tokens).ToEmbeddings()
+		if err != nil {
+			return nil, err
+		}
+		embs = append(embs, tokenEmbs)
+	}
+	return embs, nil
+}
+
+// ToMultiVectors converts the API response,
+// into a multi-vector embedding for every input.
+func (e TokenEmbeddingResponse) ToMultiVectors() ([]*embeddings.MultiVector, error) {
+	return embeddings.NewMultiVectors(e), nil
+}
+
+// Embed returns embeddings for every object in EmbeddingRequest.
+func (c *Client) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.Embedding, error) {
+	embs, err := c.Embeddings(ctx, embReq)
+	if err != nil {
+		return nil, err
+	}
+	return embs.ToEmbeddings()
+}
+
+// Embeddings fetches pooled embeddings for every input
+// in EmbeddingRequest from the embed API endpoint.
+func (c *Client) Embeddings(ctx context.Context, embReq *EmbeddingRequest) (EmbeddingResponse, error) {
+	var embs EmbeddingResponse
+	if err := c.post(ctx, "/embed", embReq, &embs); err != nil {
+		return nil, err
+	}
+	return embs, nil
+}
+
+// SparseEmbed returns sparse embeddings for every object in EmbeddingRequest.
+func (c *Client) SparseEmbed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.SparseEmbedding, error) {
+	embs, err := c.SparseEmbeddings(ctx, embReq)
+	if err != nil {
+		return nil, err
+	}
+	return embs.ToSparseEmbeddings()
+}
+
+// SparseEmbeddings fetches sparse embeddings for every input in
+// EmbeddingRequest from the sparse embed API endpoint.
+// NOTE: the server must serve a sparse model e.g. SPLADE.
+func (c *Client) SparseEmbeddings(ctx context.Context, embReq *EmbeddingRequest) (SparseEmbeddingResponse, error) {
+	req := *embReq
+	req.Normalize = nil
+
+	var embs SparseEmbeddingResponse
+	if err := c.post(ctx, "/embed_sparse", &req, &embs); err != nil {
+		return nil, err
+	}
+	return embs, nil
+}
+
+// TokenEmbeddings fetches unpooled embeddings of every token of every
+// input in EmbeddingRequest from the embed all API endpoint.
+func (c *Client) TokenEmbeddings(ctx context.Context, embReq *EmbeddingRequest) (TokenEmbeddingResponse, error) {
+	req := *embReq
+	req.Normalize = nil
+
+	var embs TokenEmbeddingResponse
+	if err := c.post(ctx, "/embed_all", &req, &embs); err != nil {
+		return nil, err
+	}
+	return embs, nil
+}
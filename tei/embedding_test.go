@@ -0,0 +1,118 @@
+package tei
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/info" {
+			assert.Equal(t, r.Method, http.MethodGet)
+			_, _ = w.Write([]byte(`{"model_id":"BAAI/bge-small-en-v1.5","model_dtype":"float32","model_type":{"embedding":{"pooling":"cls"}},"max_concurrent_requests":512,"max_input_length":512,"max_batch_tokens":16384,"max_client_batch_size":32,"auto_truncate":false,"tokenization_workers":4,"version":"1.5.0"}`))
+			return
+		}
+
+		req := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req["inputs"] == "" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"error":"` + "`inputs`" + ` cannot be empty","error_type":"Validation"}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/embed":
+			assert.Equal(t, req["inputs"], []any{"foo", "bar"})
+			assert.Equal(t, req["normalize"], false)
+			assert.Equal(t, req["truncate"], true)
+			assert.Equal(t, req["truncation_direction"], LeftTrunc.String())
+			assert.Equal(t, req["prompt_name"], "query")
+			_, _ = w.Write([]byte(`[[1.0,2.0],[3.0,4.0]]`))
+		case "/embed_sparse":
+			assert.NotContains(t, req, "normalize")
+			_, _ = w.Write([]byte(`[[{"index":3,"value":0.5},{"index":42,"value":1.5}]]`))
+		case "/embed_all":
+			assert.NotContains(t, req, "normalize")
+			_, _ = w.Write([]byte(`[[[1.0],[2.0],[3.0]]]`))
+		case "/tokenize":
+			assert.Equal(t, req["add_special_tokens"], false)
+			_, _ = w.Write([]byte(`[[{"id":7592,"text":"foo","special":false,"start":0,"stop":3}]]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	normalize := false
+	c := NewEmbedder(WithBaseURL(srv.URL))
+	embs, err := c.Embed(context.Background(), &EmbeddingRequest{
+		Inputs:              []string{"foo", "bar"},
+		Normalize:           &normalize,
+		Truncate:            true,
+		TruncationDirection: LeftTrunc,
+		PromptName:          "query",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 2)
+	assert.Equal(t, embs[1].Vector, []float64{3.0, 4.0})
+
+	_, err = c.Embed(context.Background(), &EmbeddingRequest{Inputs: ""})
+	var apiErr APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, apiErr.ErrorType, "Validation")
+}
+
+func TestSparseEmbeddings(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	normalize := true
+	c := NewClient(WithBaseURL(srv.URL))
+	embs, err := c.SparseEmbeddings(context.Background(), &EmbeddingRequest{
+		Inputs:    "foo",
+		Normalize: &normalize,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, embs, SparseEmbeddingResponse{{{Index: 3, Value: 0.5}, {Index: 42, Value: 1.5}}})
+
+	sparse, err := NewSparseEmbedder(WithBaseURL(srv.URL)).SparseEmbed(context.Background(), &EmbeddingRequest{Inputs: "foo"})
+	assert.NoError(t, err)
+	assert.Len(t, sparse, 1)
+	assert.Equal(t, sparse[0].Indices, []int{3, 42})
+	assert.Equal(t, sparse[0].Values, []float64{0.5, 1.5})
+}
+
+func TestTokenEmbeddings(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	resp, err := c.TokenEmbeddings(context.Background(), &EmbeddingRequest{Inputs: "foo"})
+	assert.NoError(t, err)
+	embs, err := resp.ToEmbeddings()
+	assert.NoError(t, err)
+	assert.Len(t, embs, 1)
+	assert.Len(t, embs[0], 3)
+	assert.Equal(t, embs[0][2].Vector, []float64{3.0})
+
+	mvs, err := resp.ToMultiVectors()
+	assert.NoError(t, err)
+	assert.Len(t, mvs, 1)
+	assert.Equal(t, mvs[0].Vectors, [][]float64{{1.0}, {2.0}, {3.0}})
+}
@@ -0,0 +1,20 @@
+package tei
+
+import (
+	"encoding/json"
+)
+
+// APIError is TEI API error.
+type APIError struct {
+	ErrorMessage string `json:"error"`
+	ErrorType    string `json:"error_type"`
+}
+
+// Error implements error interface.
+func (e APIError) Error() string {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "unknown error"
+	}
+	return string(b)
+}
@@ -0,0 +1,49 @@
+package tei
+
+import (
+	"context"
+	"net/http"
+)
+
+// ModelType is the type of the served model.
+// Only one of its fields is set.
+type ModelType struct {
+	Embedding *struct {
+		Pooling string `json:"pooling"`
+	} `json:"embedding,omitempty"`
+	Classifier *struct {
+		ID2Label map[string]string `json:"id2label"`
+		Label2ID map[string]int    `json:"label2id"`
+	} `json:"classifier,omitempty"`
+	Reranker *struct {
+		ID2Label map[string]string `json:"id2label"`
+		Label2ID map[string]int    `json:"label2id"`
+	} `json:"reranker,omitempty"`
+}
+
+// Info is the served model metadata.
+type Info struct {
+	ModelID               string    `json:"model_id"`
+	ModelSHA              string    `json:"model_sha,omitempty"`
+	ModelDType            string    `json:"model_dtype"`
+	ModelType             ModelType `json:"model_type"`
+	MaxConcurrentRequests int       `json:"max_concurrent_requests"`
+	MaxInputLength        int       `json:"max_input_length"`
+	MaxBatchTokens        int       `json:"max_batch_tokens"`
+	MaxBatchRequests      int       `json:"max_batch_requests,omitempty"`
+	MaxClientBatchSize    int       `json:"max_client_batch_size"`
+	AutoTruncate          bool      `json:"auto_truncate"`
+	TokenizationWorkers   int       `json:"tokenization_workers"`
+	Version               string    `json:"version"`
+	SHA                   string    `json:"sha,omitempty"`
+	DockerLabel           string    `json:"docker_label,omitempty"`
+}
+
+// Info returns the served model metadata.
+func (c *Client) Info(ctx context.Context) (*Info, error) {
+	info := new(Info)
+	if err := c.send(ctx, http.MethodGet, "/info", nil, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
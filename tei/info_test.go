@@ -0,0 +1,24 @@
+package tei
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfo(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	info, err := c.Info(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, info.ModelID, "BAAI/bge-small-en-v1.5")
+	assert.Equal(t, info.MaxInputLength, 512)
+	assert.NotNil(t, info.ModelType.Embedding)
+	assert.Equal(t, info.ModelType.Embedding.Pooling, "cls")
+	assert.Nil(t, info.ModelType.Classifier)
+}
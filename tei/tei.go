@@ -0,0 +1,14 @@
+package tei
+
+// TruncationDirection is the direction inputs are truncated in.
+type TruncationDirection string
+
+const (
+	LeftTrunc  TruncationDirection = "Left"
+	RightTrunc TruncationDirection = "Right"
+)
+
+// String implements stringer.
+func (t TruncationDirection) String() string {
+	return string(t)
+}
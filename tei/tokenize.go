@@ -0,0 +1,39 @@
+package tei
+
+import (
+	"context"
+)
+
+// TokenizeRequest is sent to the tokenize API endpoint.
+type TokenizeRequest struct {
+	// Inputs is either a string or a slice of strings.
+	Inputs any `json:"inputs"`
+	// AddSpecialTokens adds the model special tokens.
+	// It defaults to true on the server.
+	AddSpecialTokens *bool  `json:"add_special_tokens,omitempty"`
+	PromptName       string `json:"prompt_name,omitempty"`
+}
+
+// Token is a single input token.
+type Token struct {
+	ID      int    `json:"id"`
+	Text    string `json:"text"`
+	Special bool   `json:"special"`
+	// Start and Stop are the byte offsets of
+	// the token in the input if it's not special.
+	Start *int `json:"start"`
+	Stop  *int `json:"stop"`
+}
+
+// TokenizeResponse contains the tokens of every input.
+type TokenizeResponse [][]Token
+
+// Tokenize tokenizes every input in TokenizeRequest
+// using the tokenizer of the served model.
+func (c *Client) Tokenize(ctx context.Context, tokReq *TokenizeRequest) (TokenizeResponse, error) {
+	var tokens TokenizeResponse
+	if err := c.post(ctx, "/tokenize", tokReq, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
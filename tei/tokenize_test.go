@@ -0,0 +1,27 @@
+package tei
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	addSpecial := false
+	c := NewClient(WithBaseURL(srv.URL))
+	tokens, err := c.Tokenize(context.Background(), &TokenizeRequest{
+		Inputs:           "foo",
+		AddSpecialTokens: &addSpecial,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, tokens[0][0].ID, 7592)
+	assert.Equal(t, tokens[0][0].Text, "foo")
+	assert.Equal(t, *tokens[0][0].Stop, 3)
+}
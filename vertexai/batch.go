@@ -0,0 +1,310 @@
+package vertexai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/milosgajdos/go-embeddings"
+)
+
+const (
+	// BatchPredictionJobsURI is the batch prediction jobs API URI.
+	BatchPredictionJobsURI = "batchPredictionJobs"
+	// JSONLFormat is JSON lines batch prediction data format.
+	JSONLFormat = "jsonl"
+	// DefaultPollInterval is the default batch prediction job poll interval.
+	DefaultPollInterval = 30 * time.Second
+)
+
+// JobState is batch prediction job state.
+type JobState string
+
+const (
+	JobStateUnspecified        JobState = "JOB_STATE_UNSPECIFIED"
+	JobStateQueued             JobState = "JOB_STATE_QUEUED"
+	JobStatePending            JobState = "JOB_STATE_PENDING"
+	JobStateRunning            JobState = "JOB_STATE_RUNNING"
+	JobStateSucceeded          JobState = "JOB_STATE_SUCCEEDED"
+	JobStateFailed             JobState = "JOB_STATE_FAILED"
+	JobStateCancelling         JobState = "JOB_STATE_CANCELLING"
+	JobStateCancelled          JobState = "JOB_STATE_CANCELLED"
+	JobStatePaused             JobState = "JOB_STATE_PAUSED"
+	JobStateExpired            JobState = "JOB_STATE_EXPIRED"
+	JobStateUpdating           JobState = "JOB_STATE_UPDATING"
+	JobStatePartiallySucceeded JobState = "JOB_STATE_PARTIALLY_SUCCEEDED"
+)
+
+// String implements stringer.
+func (s JobState) String() string {
+	return string(s)
+}
+
+// Done returns true if the job has reached its final state.
+func (s JobState) Done() bool {
+	switch s {
+	case JobStateSucceeded, JobStateFailed, JobStateCancelled,
+		JobStateExpired, JobStatePartiallySucceeded:
+		return true
+	}
+	return false
+}
+
+// GCSSource is batch prediction input GCS source.
+type GCSSource struct {
+	URIs []string `json:"uris"`
+}
+
+// InputConfig configures batch prediction input.
+type InputConfig struct {
+	InstancesFormat string     `json:"instancesFormat"`
+	GCSSource       *GCSSource `json:"gcsSource,omitempty"`
+}
+
+// GCSDestination is batch prediction output GCS destination.
+type GCSDestination struct {
+	OutputURIPrefix string `json:"outputUriPrefix"`
+}
+
+// OutputConfig configures batch prediction output.
+type OutputConfig struct {
+	PredictionsFormat string          `json:"predictionsFormat"`
+	GCSDestination    *GCSDestination `json:"gcsDestination,omitempty"`
+}
+
+// OutputInfo contains batch prediction output location.
+type OutputInfo struct {
+	GCSOutputDirectory string `json:"gcsOutputDirectory,omitempty"`
+}
+
+// CompletionStats are batch prediction job statistics.
+type CompletionStats struct {
+	// NOTE: int64 values are encoded as JSON strings
+	SuccessfulCount string `json:"successfulCount,omitempty"`
+	FailedCount     string `json:"failedCount,omitempty"`
+	IncompleteCount string `json:"incompleteCount,omitempty"`
+}
+
+// JobError is batch prediction job error.
+type JobError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// BatchPredictionJob is a batch prediction job.
+// https://cloud.google.com/vertex-ai/docs/reference/rest/v1/projects.locations.batchPredictionJobs
+type BatchPredictionJob struct {
+	// Name is the job resource name.
+	// It is set by the API when the job is created.
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"displayName"`
+	// Model is the model resource name. If empty, the
+	// Google published model of the client is used.
+	// NOTE: batch prediction jobs do not use the deployed model
+	// endpoints so the client endpoint ID is ignored. Set the
+	// name of the deployed model resource to use a custom model.
+	Model           string           `json:"model"`
+	InputConfig     InputConfig      `json:"inputConfig"`
+	OutputConfig    OutputConfig     `json:"outputConfig"`
+	ModelParameters *Params          `json:"modelParameters,omitempty"`
+	State           JobState         `json:"state,omitempty"`
+	Error           *JobError        `json:"error,omitempty"`
+	OutputInfo      *OutputInfo      `json:"outputInfo,omitempty"`
+	CompletionStats *CompletionStats `json:"completionStats,omitempty"`
+	CreateTime      *time.Time       `json:"createTime,omitempty"`
+	StartTime       *time.Time       `json:"startTime,omitempty"`
+	EndTime         *time.Time       `json:"endTime,omitempty"`
+	UpdateTime      *time.Time       `json:"updateTime,omitempty"`
+}
+
+// NewBatchPredictionJob creates a new batch prediction job which reads
+// JSONL encoded instances from the inputURI and writes the JSONL encoded
+// predictions to the outputURIPrefix and returns it.
+func NewBatchPredictionJob(name, inputURI, outputURIPrefix string) *BatchPredictionJob {
+	return &BatchPredictionJob{
+		DisplayName: name,
+		InputConfig: InputConfig{
+			InstancesFormat: JSONLFormat,
+			GCSSource: &GCSSource{
+				URIs: []string{inputURI},
+			},
+		},
+		OutputConfig: OutputConfig{
+			PredictionsFormat: JSONLFormat,
+			GCSDestination: &GCSDestination{
+				OutputURIPrefix: outputURIPrefix,
+			},
+		},
+	}
+}
+
+// CreateBatchPredictionJob creates a new batch prediction job
+// in the client location and returns it. If the job Model is empty
+// the Google published model set via WithModelID is used even if
+// the client has an endpoint ID set.
+func (c *Client) CreateBatchPredictionJob(ctx context.Context, job *BatchPredictionJob) (*BatchPredictionJob, error) {
+	u, err := url.Parse(c.opts.BaseURL + "/" + c.locationPath() + "/" + BatchPredictionJobsURI)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := *job
+	if payload.Model == "" {
+		payload.Model = "publishers/" + GooglePublisher + "/models/" + c.opts.ModelID
+	}
+
+	resp := new(BatchPredictionJob)
+	if err := c.post(ctx, u.String(), &payload, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetBatchPredictionJob returns the batch prediction job.
+// The job can be either its resource name or its ID.
+func (c *Client) GetBatchPredictionJob(ctx context.Context, job string) (*BatchPredictionJob, error) {
+	u, err := c.batchJobURL(job, "")
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(BatchPredictionJob)
+	if err := c.send(ctx, http.MethodGet, u.String(), nil, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CancelBatchPredictionJob cancels the batch prediction job.
+// The job can be either its resource name or its ID.
+func (c *Client) CancelBatchPredictionJob(ctx context.Context, job string) error {
+	u, err := c.batchJobURL(job, ":cancel")
+	if err != nil {
+		return err
+	}
+
+	resp := map[string]any{}
+	return c.post(ctx, u.String(), struct{}{}, &resp)
+}
+
+// WaitBatchPredictionJob polls the batch prediction job every interval until
+// it reaches its final state or until the context is cancelled and returns it.
+// If interval is not positive DefaultPollInterval is used.
+// It returns the job with ErrJobPartiallySucceeded if some of the
+// job instances failed and with ErrJobFailed if the job did not succeed.
+func (c *Client) WaitBatchPredictionJob(ctx context.Context, job string, interval time.Duration) (*BatchPredictionJob, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		j, err := c.GetBatchPredictionJob(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+		if j.State.Done() {
+			switch j.State {
+			case JobStateSucceeded:
+				return j, nil
+			case JobStatePartiallySucceeded:
+				return j, ErrJobPartiallySucceeded
+			}
+			return j, ErrJobFailed
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// batchJobURL returns the batch prediction job URL with the given action.
+func (c *Client) batchJobURL(job, action string) (*url.URL, error) {
+	id := job[strings.LastIndex(job, "/")+1:]
+	return url.Parse(c.opts.BaseURL + "/" + c.locationPath() + "/" + BatchPredictionJobsURI + "/" + url.PathEscape(id) + action)
+}
+
+// WriteBatchInstances writes instances as JSON lines into w.
+// The output can be used as the batch prediction job input.
+func WriteBatchInstances(w io.Writer, instances []Instance) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, inst := range instances {
+		if err := enc.Encode(inst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BatchPrediction is a single batch prediction output record.
+type BatchPrediction struct {
+	Instance    Instance      `json:"instance"`
+	Predictions []Predictions `json:"predictions"`
+	// Status is empty if the prediction succeeded.
+	Status string `json:"status"`
+}
+
+// ReadBatchPredictions reads JSON lines encoded
+// batch prediction output records from r and returns them.
+func ReadBatchPredictions(r io.Reader) ([]*BatchPrediction, error) {
+	// nolint:prealloc
+	var preds []*BatchPrediction
+
+	scanner := bufio.NewScanner(r)
+	// NOTE: embedding records are larger than the default buffer
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		p := new(BatchPrediction)
+		if err := json.Unmarshal(line, p); err != nil {
+			return nil, err
+		}
+		preds = append(preds, p)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return preds, nil
+}
+
+// BatchPredictionsToEmbeddings converts the successful batch predictions
+// into a slice of embeddings and returns it along with the instances
+// the embeddings were generated for. The output records of batch prediction
+// jobs are not guaranteed to be in the same order as the input instances.
+func BatchPredictionsToEmbeddings(preds []*BatchPrediction) ([]Instance, []*embeddings.Embedding, error) {
+	instances := make([]Instance, 0, len(preds))
+	embs := make([]*embeddings.Embedding, 0, len(preds))
+	for _, p := range preds {
+		if p.Status != "" {
+			continue
+		}
+		resp := &EmbedddingResponse{Predictions: p.Predictions}
+		pEmbs, err := resp.ToEmbeddings()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, emb := range pEmbs {
+			instances = append(instances, p.Instance)
+			embs = append(embs, emb)
+		}
+	}
+	return instances, embs, nil
+}
@@ -0,0 +1,122 @@
+package vertexai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchPredictionJob(t *testing.T) {
+	t.Parallel()
+
+	const (
+		jobsPath = "/project/locations/europe-west4/batchPredictionJobs"
+		jobName  = "projects/project/locations/europe-west4/batchPredictionJobs/123"
+	)
+
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get("Authorization"), "Bearer "+vertexaiToken)
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == jobsPath:
+			job := new(BatchPredictionJob)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(job))
+			assert.Equal(t, job.Model, "publishers/google/models/text-embedding-005")
+			assert.Equal(t, job.InputConfig.GCSSource.URIs, []string{"gs://foo/input.jsonl"})
+			job.Name = jobName
+			job.State = JobStatePending
+			assert.NoError(t, json.NewEncoder(w).Encode(job))
+		case r.Method == http.MethodGet && r.URL.Path == jobsPath+"/123":
+			state := JobStateRunning
+			if polls.Add(1) > 1 {
+				state = JobStateSucceeded
+			}
+			_, _ = w.Write([]byte(`{"name":"` + jobName + `","state":"` + state.String() + `","outputInfo":{"gcsOutputDirectory":"gs://foo/out"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == jobsPath+"/456":
+			_, _ = w.Write([]byte(`{"name":"456","state":"JOB_STATE_PARTIALLY_SUCCEEDED","completionStats":{"successfulCount":"1","failedCount":"1"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == jobsPath+"/789":
+			_, _ = w.Write([]byte(`{"name":"789","state":"JOB_STATE_FAILED","error":{"code":3,"message":"invalid input"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == jobsPath+"/123:cancel":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found","status":"NOT_FOUND"}}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(
+		WithToken(vertexaiToken),
+		WithProjectID("project"),
+		WithLocation("europe-west4"),
+		WithModelID(EmbedTextV5.String()),
+		WithBaseURL(srv.URL),
+	)
+	ctx := context.Background()
+
+	job, err := c.CreateBatchPredictionJob(ctx, NewBatchPredictionJob("foo", "gs://foo/input.jsonl", "gs://foo/out"))
+	assert.NoError(t, err)
+	assert.Equal(t, job.Name, jobName)
+	assert.Equal(t, job.State, JobStatePending)
+
+	job, err = c.WaitBatchPredictionJob(ctx, job.Name, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, job.State, JobStateSucceeded)
+	assert.Equal(t, job.OutputInfo.GCSOutputDirectory, "gs://foo/out")
+	assert.Equal(t, polls.Load(), int32(2))
+
+	job, err = c.WaitBatchPredictionJob(ctx, "456", time.Millisecond)
+	assert.ErrorIs(t, err, ErrJobPartiallySucceeded)
+	assert.NotErrorIs(t, err, ErrJobFailed)
+	assert.Equal(t, job.CompletionStats.SuccessfulCount, "1")
+
+	job, err = c.WaitBatchPredictionJob(ctx, "789", time.Millisecond)
+	assert.ErrorIs(t, err, ErrJobFailed)
+	assert.Equal(t, job.Error.Message, "invalid input")
+
+	assert.NoError(t, c.CancelBatchPredictionJob(ctx, "123"))
+
+	_, err = c.GetBatchPredictionJob(ctx, "000")
+	assert.ErrorAs(t, err, &APIError{})
+}
+
+func TestBatchPredictions(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	instances := []Instance{
+		{Content: "foo", TaskType: RetrDocTask},
+		{Content: "bar", TaskType: RetrDocTask},
+	}
+	assert.NoError(t, WriteBatchInstances(&buf, instances))
+	assert.Equal(t, strings.Count(buf.String(), "\n"), 2)
+
+	output := `{"instance":{"task_type":"RETRIEVAL_DOCUMENT","content":"bar"},"predictions":[{"embeddings":{"values":[2.0],"statistics":{"token_count":1,"truncated":false}}}],"status":""}
+
+{"instance":{"task_type":"RETRIEVAL_DOCUMENT","content":"baz"},"predictions":[],"status":"error"}
+{"instance":{"task_type":"RETRIEVAL_DOCUMENT","content":"foo"},"predictions":[{"embeddings":{"values":[1.0],"statistics":{"token_count":1,"truncated":false}}}],"status":""}
+`
+	preds, err := ReadBatchPredictions(strings.NewReader(output))
+	assert.NoError(t, err)
+	assert.Len(t, preds, 3)
+
+	insts, embs, err := BatchPredictionsToEmbeddings(preds)
+	assert.NoError(t, err)
+	assert.Len(t, embs, 2)
+	assert.Equal(t, insts[0].Content, "bar")
+	assert.Equal(t, embs[0].Vector, []float64{2.0})
+	assert.Equal(t, insts[1].Content, "foo")
+	assert.Equal(t, embs[1].Vector, []float64{1.0})
+
+	_, err = ReadBatchPredictions(strings.NewReader("garbage"))
+	assert.Error(t, err)
+}
@@ -10,24 +10,42 @@ import (
 
 const (
 	// BaseURL is the Google Vertex AI HTTP API base URL
+	// in the default location.
 	BaseURL = "https://us-central1-aiplatform.googleapis.com/v1/projects"
-	// ModelURI is the Google Vertex AI HTTP API model URI.
+	// ModelURI is the Google Vertex AI HTTP API model URI
+	// in the default location.
+	// Deprecated: the model URI is built from the client location.
 	ModelURI = "locations/us-central1/publishers/google/models"
 	// EmbedAction is embedding API action.
 	EmbedAction = ":predict"
+	// DefaultLocation is the default Google Cloud location.
+	DefaultLocation = "us-central1"
+	// GlobalLocation is the Google Cloud global location.
+	GlobalLocation = "global"
+	// GooglePublisher is the publisher of Google models.
+	GooglePublisher = "google"
 )
 
 // Client is a Google Vertex AI HTTP API client.
 type Client struct {
 	opts Options
+	ts   *tokenSource
 }
 
 // Options are client options
 type Options struct {
-	Token      string
-	TokenSrc   oauth2.TokenSource
+	Token    string
+	TokenSrc oauth2.TokenSource
+	// CredentialsFile is the path to JSON encoded Google credentials.
+	CredentialsFile string
+	// CredentialsJSON are JSON encoded Google credentials.
+	CredentialsJSON []byte
+	// Scopes are OAuth2 scopes requested with the credentials.
+	Scopes     []string
 	ProjectID  string
 	ModelID    string
+	Location   string
+	EndpointID string
 	BaseURL    string
 	HTTPClient *client.HTTP
 }
@@ -40,25 +58,53 @@ type Option func(*Options)
 // * VERTEXAI_TOKEN for setting the API token
 // * VERTEXAI_MODEL_ID for settings the API model ID
 // * GOOGLE_PROJECT_ID for setting the Google Project ID
+// * VERTEXAI_LOCATION for setting the Google Cloud location
+// * GOOGLE_APPLICATION_CREDENTIALS for setting the credentials file path
+// * VERTEXAI_CREDENTIALS_JSON for setting the JSON encoded credentials
 // It uses the default Go http.Client for making API requests
-// to the BaseURL. You can override the default client options
-// via the client methods.
-// NOTE: you must provide either the token or the token source
+// to the regional API endpoint in the DefaultLocation.
+// You can override the default client options via the client methods.
+// NOTE: you must provide either the token, the token source or the credentials.
+// If more of them are provided the static token takes precedence
+// over the token source which takes precedence over the credentials.
+// If the project ID is not set it's read from the service account key.
 func NewClient(opts ...Option) *Client {
 	options := Options{
 		Token:      os.Getenv("VERTEXAI_TOKEN"),
 		ModelID:    os.Getenv("VERTEXAI_MODEL_ID"),
 		ProjectID:  os.Getenv("GOOGLE_PROJECT_ID"),
-		BaseURL:    BaseURL,
+		Location:   os.Getenv("VERTEXAI_LOCATION"),
 		HTTPClient: client.NewHTTP(),
+		// NOTE: credentials file is only read if no credentials JSON is set.
+		CredentialsFile: os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"),
+		Scopes:          []string{Scopes},
+	}
+	if creds := os.Getenv("VERTEXAI_CREDENTIALS_JSON"); creds != "" {
+		options.CredentialsJSON = []byte(creds)
 	}
 
 	for _, apply := range opts {
 		apply(&options)
 	}
 
+	if options.Token == "" && options.TokenSrc == nil {
+		var creds []byte
+		options.TokenSrc, creds = credentialsTokenSource(&options)
+		if options.ProjectID == "" {
+			options.ProjectID = credentialsProjectID(creds)
+		}
+	}
+
+	if options.Location == "" {
+		options.Location = DefaultLocation
+	}
+	if options.BaseURL == "" {
+		options.BaseURL = LocationURL(options.Location)
+	}
+
 	return &Client{
 		opts: options,
+		ts:   newTokenSource(options.Token, options.TokenSrc),
 	}
 }
 
@@ -75,14 +121,53 @@ func WithToken(token string) Option {
 }
 
 // WithTokenSrc sets the API token source.
-// The source can be used for generating the API token
-// if no token has been set.
+// The source is used for generating the API token
+// if no token has been set. The fetched token is reused
+// until it expires or until the API rejects it.
+// NOTE: if ts caches tokens itself e.g. oauth2.ReuseTokenSource
+// the token can't be forcibly refreshed when the API rejects it.
+// NewCredentialsTokenSource and NewDefaultTokenSource create
+// token sources which don't cache the tokens.
 func WithTokenSrc(ts oauth2.TokenSource) Option {
 	return func(o *Options) {
 		o.TokenSrc = ts
 	}
 }
 
+// WithCredentialsFile sets the path to the JSON encoded Google credentials
+// file which is used for creating the token source if no token or token
+// source has been set. The credentials can be either a service account key
+// or a workload identity federation config.
+// NOTE: the file is read when the client is created but the
+// errors are only returned by the API calls. Create the token
+// source with NewCredentialsTokenSource and set it via WithTokenSrc
+// to handle the credentials errors when creating the client.
+func WithCredentialsFile(path string) Option {
+	return func(o *Options) {
+		o.CredentialsFile = path
+		o.CredentialsJSON = nil
+	}
+}
+
+// WithCredentialsJSON sets the JSON encoded Google credentials
+// which are used for creating the token source if no token or token
+// source has been set. The credentials can be either a service account
+// key or a workload identity federation config.
+// NOTE: the invalid credentials errors are only returned by the API calls,
+// see WithCredentialsFile.
+func WithCredentialsJSON(data []byte) Option {
+	return func(o *Options) {
+		o.CredentialsJSON = data
+	}
+}
+
+// WithScopes sets the OAuth2 scopes requested with the credentials.
+func WithScopes(scopes ...string) Option {
+	return func(o *Options) {
+		o.Scopes = scopes
+	}
+}
+
 // WithProjectID sets the Google Project ID.
 func WithProjectID(id string) Option {
 	return func(o *Options) {
@@ -98,7 +183,30 @@ func WithModelID(id string) Option {
 	}
 }
 
+// WithLocation sets the Google Cloud location e.g. europe-west4.
+// Unless the base URL is set explicitly the requests are sent
+// to the API endpoint in the given location.
+func WithLocation(location string) Option {
+	return func(o *Options) {
+		o.Location = location
+	}
+}
+
+// WithEndpointID sets the ID of the deployed model endpoint.
+// If set, the requests are sent to the endpoint instead of the
+// Google published model set via WithModelID.
+// https://cloud.google.com/vertex-ai/docs/predictions/get-online-predictions
+func WithEndpointID(id string) Option {
+	return func(o *Options) {
+		o.EndpointID = id
+	}
+}
+
 // WithBaseURL sets the API base URL.
+// It can be used for targeting custom endpoints,
+// such as private service connect endpoints.
+// NOTE: the base URL must contain the API version
+// and the projects path e.g. https://host/v1/projects
 func WithBaseURL(baseURL string) Option {
 	return func(o *Options) {
 		o.BaseURL = baseURL
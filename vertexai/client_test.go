@@ -72,6 +72,54 @@ func TestClient(t *testing.T) {
 		assert.Equal(t, c.opts.BaseURL, testVal)
 	})
 
+	t.Run("location", func(t *testing.T) {
+		c := NewClient()
+		assert.Equal(t, c.opts.Location, DefaultLocation)
+
+		testVal := "europe-west4"
+		c = NewClient(WithLocation(testVal))
+		assert.Equal(t, c.opts.Location, testVal)
+		assert.Equal(t, c.opts.BaseURL, "https://europe-west4-aiplatform.googleapis.com/v1/projects")
+
+		c = NewClient(WithLocation(GlobalLocation))
+		assert.Equal(t, c.opts.BaseURL, "https://aiplatform.googleapis.com/v1/projects")
+
+		t.Setenv("VERTEXAI_LOCATION", testVal)
+		c = NewClient()
+		assert.Equal(t, c.opts.Location, testVal)
+	})
+
+	t.Run("endpoint id", func(t *testing.T) {
+		c := NewClient()
+		assert.Empty(t, c.opts.EndpointID)
+
+		testVal := "123"
+		c = NewClient(WithEndpointID(testVal))
+		assert.Equal(t, c.opts.EndpointID, testVal)
+	})
+
+	t.Run("predict URL", func(t *testing.T) {
+		c := NewClient()
+		u, err := c.predictURL()
+		assert.NoError(t, err)
+		assert.Equal(t, u.String(), BaseURL+"/"+googleProjectID+"/"+ModelURI+"/"+vertexaiModel+EmbedAction)
+
+		c = NewClient(WithLocation("europe-west4"))
+		u, err = c.predictURL()
+		assert.NoError(t, err)
+		assert.Equal(t, u.String(), "https://europe-west4-aiplatform.googleapis.com/v1/projects/project/locations/europe-west4/publishers/google/models/model:predict")
+
+		pscURL := "https://123.europe-west4-456.prediction.vertexai.goog/v1/projects"
+		c = NewClient(
+			WithLocation("europe-west4"),
+			WithEndpointID("123"),
+			WithBaseURL(pscURL),
+		)
+		u, err = c.predictURL()
+		assert.NoError(t, err)
+		assert.Equal(t, u.String(), pscURL+"/project/locations/europe-west4/endpoints/123:predict")
+	})
+
 	t.Run("http client", func(t *testing.T) {
 		c := NewClient()
 		assert.NotNil(t, c.opts.HTTPClient)
@@ -0,0 +1,85 @@
+package vertexai
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// NewCredentialsTokenSource creates a new token source from the JSON
+// encoded Google credentials and returns it. The credentials can be
+// either a service account key or a workload identity federation config.
+// If no scopes are given the token source requests Scopes.
+// The returned token source does not reuse tokens: it fetches a new token
+// on every call, so the client can refresh the tokens rejected by the API.
+// Wrap it in oauth2.ReuseTokenSource when using it outside of the client.
+func NewCredentialsTokenSource(ctx context.Context, data []byte, scopes ...string) (oauth2.TokenSource, error) {
+	if len(scopes) == 0 {
+		scopes = []string{Scopes}
+	}
+	newSrc := func() (oauth2.TokenSource, error) {
+		creds, err := google.CredentialsFromJSON(ctx, data, scopes...)
+		if err != nil {
+			return nil, err
+		}
+		return creds.TokenSource, nil
+	}
+	// NOTE: the credentials are parsed here so that
+	// the invalid credentials are reported immediately.
+	if _, err := newSrc(); err != nil {
+		return nil, err
+	}
+	return freshTokenSource{newSrc: newSrc}, nil
+}
+
+// credentialsProjectID returns the project ID stored in
+// the JSON encoded service account key. It returns empty
+// string if it's not found e.g. in workload identity configs.
+func credentialsProjectID(data []byte) string {
+	var creds struct {
+		ProjectID string `json:"project_id"`
+	}
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return ""
+	}
+	return creds.ProjectID
+}
+
+// errTokenSource is a token source which always fails.
+// It's used for deferring credentials errors to API calls.
+type errTokenSource struct {
+	err error
+}
+
+// Token implements oauth2.TokenSource.
+func (e errTokenSource) Token() (*oauth2.Token, error) {
+	return nil, e.err
+}
+
+// credentialsTokenSource creates a token source from the credentials
+// options and returns it along with the raw credentials. It returns
+// nil token source if no credentials have been configured.
+func credentialsTokenSource(o *Options) (oauth2.TokenSource, []byte) {
+	data := o.CredentialsJSON
+	if len(data) == 0 && o.CredentialsFile != "" {
+		var err error
+		// nolint:gosec
+		data, err = os.ReadFile(o.CredentialsFile)
+		if err != nil {
+			return errTokenSource{err: err}, nil
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	ts, err := NewCredentialsTokenSource(context.Background(), data, o.Scopes...)
+	if err != nil {
+		return errTokenSource{err: err}, nil
+	}
+
+	return ts, data
+}
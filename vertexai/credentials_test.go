@@ -0,0 +1,162 @@
+package vertexai
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serviceAccountKey(t *testing.T, tokenURI string) []byte {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "sa-project",
+		"private_key_id": "keyid",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email":   "sa@sa-project.iam.gserviceaccount.com",
+		"client_id":      "123",
+		"token_uri":      tokenURI,
+	})
+	assert.NoError(t, err)
+
+	return data
+}
+
+func TestCredentials(t *testing.T) {
+	t.Setenv("VERTEXAI_TOKEN", "")
+	t.Setenv("GOOGLE_PROJECT_ID", "")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	t.Setenv("VERTEXAI_CREDENTIALS_JSON", "")
+
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.NotEmpty(t, r.Form.Get("assertion"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"sa-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenSrv.Close()
+
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get("Authorization"), "Bearer sa-token")
+		assert.Contains(t, r.URL.Path, "/sa-project/")
+		_, _ = w.Write([]byte(`{"predictions":[{"embeddings":{"values":[1.0]}}]}`))
+	}))
+	defer apiSrv.Close()
+
+	key := serviceAccountKey(t, tokenSrv.URL)
+	embReq := &EmbeddingRequest{
+		Instances: []Instance{{Content: "foo"}},
+	}
+
+	t.Run("no credentials", func(t *testing.T) {
+		c := NewClient()
+		assert.Nil(t, c.opts.TokenSrc)
+		_, err := c.Embed(context.Background(), embReq)
+		assert.ErrorIs(t, err, ErrMissingTokenSource)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		c := NewClient(WithCredentialsJSON(key), WithBaseURL(apiSrv.URL))
+		assert.NotNil(t, c.opts.TokenSrc)
+		assert.Equal(t, c.opts.ProjectID, "sa-project")
+		assert.Equal(t, c.opts.Scopes, []string{Scopes})
+
+		embs, err := c.Embed(context.Background(), embReq)
+		assert.NoError(t, err)
+		assert.Len(t, embs, 1)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "key.json")
+		assert.NoError(t, os.WriteFile(path, key, 0600))
+
+		c := NewClient(WithCredentialsFile(path), WithBaseURL(apiSrv.URL))
+		assert.Equal(t, c.opts.ProjectID, "sa-project")
+		embs, err := c.Embed(context.Background(), embReq)
+		assert.NoError(t, err)
+		assert.Len(t, embs, 1)
+
+		t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+		c = NewClient(WithBaseURL(apiSrv.URL), WithProjectID("sa-project"))
+		embs, err = c.Embed(context.Background(), embReq)
+		assert.NoError(t, err)
+		assert.Len(t, embs, 1)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		var calls atomic.Int32
+		tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			n := calls.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"access_token":"sa-token-%d","token_type":"Bearer","expires_in":3600}`, n)
+		}))
+		defer tokenSrv.Close()
+
+		apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer sa-token-2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":{"code":401,"message":"invalid credentials","status":"UNAUTHENTICATED"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"predictions":[{"embeddings":{"values":[1.0]}}]}`))
+		}))
+		defer apiSrv.Close()
+
+		c := NewClient(WithCredentialsJSON(serviceAccountKey(t, tokenSrv.URL)), WithBaseURL(apiSrv.URL))
+		embs, err := c.Embed(context.Background(), embReq)
+		assert.NoError(t, err)
+		assert.Len(t, embs, 1)
+		assert.Equal(t, int32(2), calls.Load())
+
+		// the token is reused until the API rejects it
+		_, err = c.Embed(context.Background(), embReq)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("invalid token source", func(t *testing.T) {
+		_, err := NewCredentialsTokenSource(context.Background(), []byte("garbage"))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		c := NewClient(WithCredentialsFile(filepath.Join(t.TempDir(), "missing.json")))
+		_, err := c.Embed(context.Background(), embReq)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		c := NewClient(WithCredentialsJSON([]byte("garbage")))
+		_, err := c.Embed(context.Background(), embReq)
+		assert.Error(t, err)
+	})
+
+	t.Run("precedence", func(t *testing.T) {
+		c := NewClient(WithToken("foo"), WithCredentialsJSON(key))
+		assert.Nil(t, c.opts.TokenSrc)
+		assert.Empty(t, c.opts.ProjectID)
+	})
+
+	t.Run("scopes", func(t *testing.T) {
+		c := NewClient(WithScopes("foo"))
+		assert.Equal(t, c.opts.Scopes, []string{"foo"})
+	})
+}
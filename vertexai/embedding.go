@@ -4,8 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/milosgajdos/go-embeddings"
 	"github.com/milosgajdos/go-embeddings/request"
@@ -30,7 +31,34 @@ type Instance struct {
 type Params struct {
 	// If set to false, text that exceeds the token limit (3.072)
 	// causes the request to fail. The default value is true
-	AutoTruncate bool `json:"autoTruncate"`
+	// which is used by the API if AutoTruncate is nil.
+	AutoTruncate *bool `json:"autoTruncate,omitempty"`
+	// OutputDimensionality is the size of the output embeddings.
+	// If set, the embeddings are truncated to the given size.
+	// NOTE: only supported by text-embedding-004 and later models.
+	OutputDimensionality int `json:"outputDimensionality,omitempty"`
+}
+
+// Validate validates the request against the limits of the given model.
+// It returns nil if the model limits are not known.
+// NOTE: the input token counts are not validated, they're only known to the API.
+func (e *EmbeddingRequest) Validate(model Model) error {
+	limits, ok := model.Limits()
+	if !ok {
+		return nil
+	}
+	if n := len(e.Instances); n > limits.MaxInstances {
+		return fmt.Errorf("%w: %d instances, %s allows at most %d", ErrTooManyInstances, n, model, limits.MaxInstances)
+	}
+	if dims := e.Params.OutputDimensionality; dims != 0 {
+		if limits.MaxDims == 0 {
+			return fmt.Errorf("%w: %s does not support output dimensionality", ErrInvalidDimensions, model)
+		}
+		if dims < limits.MinDims || dims > limits.MaxDims {
+			return fmt.Errorf("%w: %d, %s allows %d-%d", ErrInvalidDimensions, dims, model, limits.MinDims, limits.MaxDims)
+		}
+	}
+	return nil
 }
 
 // EmbedddingResponse received from API endpoint.
@@ -71,45 +99,81 @@ type Statistics struct {
 
 // Embed returns embeddings for every object in EmbeddingRequest.
 func (c *Client) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.Embedding, error) {
-	u, err := url.Parse(c.opts.BaseURL + "/" + c.opts.ProjectID + "/" + ModelURI + "/" + c.opts.ModelID + EmbedAction)
+	if c.opts.EndpointID == "" {
+		if err := embReq.Validate(Model(c.opts.ModelID)); err != nil {
+			return nil, err
+		}
+	}
+
+	u, err := c.predictURL()
 	if err != nil {
 		return nil, err
 	}
 
-	var body = &bytes.Buffer{}
-	enc := json.NewEncoder(body)
-	enc.SetEscapeHTML(false)
-	if err := enc.Encode(embReq); err != nil {
+	e := new(EmbedddingResponse)
+	if err := c.post(ctx, u.String(), embReq, e); err != nil {
 		return nil, err
 	}
 
-	if c.opts.Token == "" {
-		var err error
-		c.opts.Token, err = GetToken(c.opts.TokenSrc)
-		if err != nil {
-			return nil, err
-		}
-	}
+	return e.ToEmbeddings()
+}
 
-	options := []request.Option{
-		request.WithBearer(c.opts.Token),
-	}
+// post sends the payload to the given API URL and decodes the API response into resp.
+func (c *Client) post(ctx context.Context, u string, payload, resp any) error {
+	return c.send(ctx, http.MethodPost, u, payload, resp)
+}
 
-	req, err := request.NewHTTP(ctx, http.MethodPost, u.String(), body, options...)
-	if err != nil {
-		return nil, err
+// send sends the payload to the given API URL using the given HTTP method
+// and decodes the API response into resp. The payload is not sent if it's nil.
+func (c *Client) send(ctx context.Context, method, u string, payload, resp any) error {
+	var body = &bytes.Buffer{}
+	if payload != nil {
+		enc := json.NewEncoder(body)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(payload); err != nil {
+			return err
+		}
 	}
 
-	resp, err := request.Do[APIError](c.opts.HTTPClient, req)
+	res, err := c.do(ctx, method, u, body.Bytes())
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
+	defer res.Body.Close()
 
-	e := new(EmbedddingResponse)
-	if err := json.NewDecoder(resp.Body).Decode(e); err != nil {
-		return nil, err
-	}
+	return json.NewDecoder(res.Body).Decode(resp)
+}
 
-	return e.ToEmbeddings()
+// do sends the body to the given API URL and returns the response.
+// It authenticates every request with a valid token and if the API rejects
+// the token it refreshes the token and retries the request once.
+func (c *Client) do(ctx context.Context, method, u string, body []byte) (*http.Response, error) {
+	for retry := true; ; retry = false {
+		token, err := c.ts.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		options := []request.Option{
+			request.WithBearer(token.AccessToken),
+		}
+
+		req, err := request.NewHTTP(ctx, method, u, bytes.NewReader(body), options...)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := request.Do[APIError](c.opts.HTTPClient, req)
+		if err != nil {
+			var apiErr APIError
+			if retry && errors.As(err, &apiErr) &&
+				apiErr.RespError.Code == http.StatusUnauthorized &&
+				c.ts.invalidate(token) {
+				continue
+			}
+			return nil, err
+		}
+
+		return resp, nil
+	}
 }
@@ -0,0 +1,66 @@
+package vertexai
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddingRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		model     Model
+		instances int
+		dims      int
+		content   string
+		err       error
+	}{
+		{name: "unknown model", model: "foo", instances: 1000, dims: 10000},
+		{name: "valid", model: EmbedTextV5, instances: 250, dims: 256},
+		{name: "default dims", model: EmbedGeckoV3, instances: 1},
+		{name: "too many instances", model: EmbedGemini, instances: 2, err: ErrTooManyInstances},
+		{name: "dims unsupported", model: EmbedGeckoV3, instances: 1, dims: 256, err: ErrInvalidDimensions},
+		{name: "dims too large", model: EmbedTextV4, instances: 1, dims: 1024, err: ErrInvalidDimensions},
+		{name: "gemini dims", model: EmbedGemini, instances: 1, dims: 3072},
+		{name: "long content", model: EmbedTextV5, instances: 1, content: strings.Repeat("a", 4*2048+1)},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			req := &EmbeddingRequest{
+				Instances: make([]Instance, tc.instances),
+				Params: Params{
+					OutputDimensionality: tc.dims,
+				},
+			}
+			for i := range req.Instances {
+				req.Instances[i].Content = tc.content
+			}
+			err := req.Validate(tc.model)
+			if tc.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestParamsJSON(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(Params{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(data))
+
+	autoTruncate := false
+	data, err = json.Marshal(Params{AutoTruncate: &autoTruncate})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"autoTruncate":false}`, string(data))
+}
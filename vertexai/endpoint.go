@@ -0,0 +1,41 @@
+package vertexai
+
+import (
+	"net/url"
+)
+
+// LocationHost returns the API host for the given location.
+func LocationHost(location string) string {
+	if location == "" || location == GlobalLocation {
+		return "aiplatform.googleapis.com"
+	}
+	return location + "-aiplatform.googleapis.com"
+}
+
+// LocationURL returns the API base URL for the given location.
+func LocationURL(location string) string {
+	return "https://" + LocationHost(location) + "/v1/projects"
+}
+
+// locationPath returns the path to the client
+// location relative to the base URL.
+func (c *Client) locationPath() string {
+	return url.PathEscape(c.opts.ProjectID) + "/locations/" + url.PathEscape(c.opts.Location)
+}
+
+// resourcePath returns the path to the API resource
+// relative to the base URL. If the client has an endpoint ID
+// the path points to the deployed endpoint. Otherwise it
+// points to the Google published model.
+func (c *Client) resourcePath() string {
+	path := c.locationPath()
+	if c.opts.EndpointID != "" {
+		return path + "/endpoints/" + url.PathEscape(c.opts.EndpointID)
+	}
+	return path + "/publishers/" + GooglePublisher + "/models/" + url.PathEscape(c.opts.ModelID)
+}
+
+// predictURL returns the prediction API URL.
+func (c *Client) predictURL() (*url.URL, error) {
+	return url.Parse(c.opts.BaseURL + "/" + c.resourcePath() + EmbedAction)
+}
@@ -1,6 +1,21 @@
 package vertexai
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrTooManyInstances is returned when the request exceeds the model instance limit.
+	ErrTooManyInstances = errors.New("too many instances")
+	// ErrInvalidDimensions is returned when the requested output dimensionality is not supported by the model.
+	ErrInvalidDimensions = errors.New("invalid output dimensionality")
+	// ErrJobFailed is returned when the batch prediction job did not succeed.
+	ErrJobFailed = errors.New("batch prediction job failed")
+	// ErrJobPartiallySucceeded is returned when some of the batch prediction job instances failed.
+	// The predictions of the successful instances are written to the job output.
+	ErrJobPartiallySucceeded = errors.New("batch prediction job partially succeeded")
+)
 
 // APIError is API error.
 type APIError struct {
@@ -1,16 +1,14 @@
 package vertexai
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
-	"net/url"
+	"encoding/base64"
 
-	"github.com/milosgajdos/go-embeddings/request"
+	"github.com/milosgajdos/go-embeddings"
 )
 
 // MultiEmbeddingRequest is multimodal embedding request.
+// https://cloud.google.com/vertex-ai/generative-ai/docs/model-reference/multimodal-embeddings-api
 type MultiEmbeddingRequest struct {
 	Instances []MultiInstance `json:"instances"`
 	Params    *MultiParams    `json:"parameters,omitempty"`
@@ -19,19 +17,96 @@ type MultiEmbeddingRequest struct {
 // MultiInstance contains the request payload.
 type MultiInstance struct {
 	Text  *string `json:"text,omitempty"`
-	Image any     `json:"image,omitempty"`
+	Image *Image  `json:"image,omitempty"`
+	Video *Video  `json:"video,omitempty"`
+}
+
+// Image is multimodal image input.
+// Either GCSURI or Bytes must be set.
+type Image struct {
+	// GCSURI is the GCS URI of the image file.
+	GCSURI string `json:"gcsUri,omitempty"`
+	// Bytes is the image encoded as base64 string.
+	Bytes    string `json:"bytesBase64Encoded,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// NewImageGCS creates image input stored in GCS and returns it.
+func NewImageGCS(uri string) *Image {
+	return &Image{
+		GCSURI: uri,
+	}
+}
+
+// NewImageBytes creates image input from raw image data and returns it.
+func NewImageBytes(data []byte) *Image {
+	return &Image{
+		Bytes: base64.StdEncoding.EncodeToString(data),
+	}
 }
 
 // ImageGCS contains GCS URI to image file.
+// Deprecated: use NewImageGCS.
 type ImageGCS struct {
 	URI string `json:"gcsUri"`
 }
 
+// Image converts the GCS image into Image and returns it.
+func (i ImageGCS) Image() *Image {
+	return NewImageGCS(i.URI)
+}
+
 // ImageBase64 contains image encoded as base64 string.
+// Deprecated: use NewImageBytes.
 type ImageBase64 struct {
 	Bytes string `json:"bytesBase64Encoded"`
 }
 
+// Image converts the base64 encoded image into Image and returns it.
+func (i ImageBase64) Image() *Image {
+	return &Image{
+		Bytes: i.Bytes,
+	}
+}
+
+// Video is multimodal video input.
+// Either GCSURI or Bytes must be set.
+type Video struct {
+	// GCSURI is the GCS URI of the video file.
+	GCSURI string `json:"gcsUri,omitempty"`
+	// Bytes is the video encoded as base64 string.
+	Bytes         string              `json:"bytesBase64Encoded,omitempty"`
+	SegmentConfig *VideoSegmentConfig `json:"videoSegmentConfig,omitempty"`
+}
+
+// NewVideoGCS creates video input stored in GCS and returns it.
+func NewVideoGCS(uri string, config *VideoSegmentConfig) *Video {
+	return &Video{
+		GCSURI:        uri,
+		SegmentConfig: config,
+	}
+}
+
+// NewVideoBytes creates video input from raw video data and returns it.
+func NewVideoBytes(data []byte, config *VideoSegmentConfig) *Video {
+	return &Video{
+		Bytes:         base64.StdEncoding.EncodeToString(data),
+		SegmentConfig: config,
+	}
+}
+
+// VideoSegmentConfig configures which part of the video is embedded
+// and the interval at which the video segment embeddings are generated.
+// NOTE: the API defaults to embedding the first 120 seconds
+// of the video in 16 seconds long segments.
+type VideoSegmentConfig struct {
+	// NOTE: StartOffsetSec is a pointer so that
+	// the explicit zero offset is sent to the API.
+	StartOffsetSec *int `json:"startOffsetSec,omitempty"`
+	EndOffsetSec   int  `json:"endOffsetSec,omitempty"`
+	IntervalSec    int  `json:"intervalSec,omitempty"`
+}
+
 // MultiParams are additional API request parameters.
 type MultiParams struct {
 	Dimension uint `json:"dimension"`
@@ -43,53 +118,134 @@ type MultiEmbedddingResponse struct {
 	ModelID     string            `json:"deployedModelId"`
 }
 
-// MultiPrediction for a given request.
-type MultiPrediction struct {
-	Image []float64 `json:"imageEmbedding"`
-	Text  []float64 `json:"textEmbedding"`
+// ToMultiEmbeddings converts the API response into a slice
+// of multimodal embeddings in the order of the request instances
+// and returns it. The embeddings of every instance are ordered
+// by their modality: text, image and video segments.
+func (e *MultiEmbedddingResponse) ToMultiEmbeddings() ([]*MultiEmbedding, error) {
+	// nolint:prealloc
+	var embs []*MultiEmbedding
+	for i, p := range e.Predictions {
+		if p.Text != nil {
+			embs = append(embs, newMultiEmbedding(i, TextModality, p.Text))
+		}
+		if p.Image != nil {
+			embs = append(embs, newMultiEmbedding(i, ImageModality, p.Image))
+		}
+		for _, v := range p.Video {
+			emb := newMultiEmbedding(i, VideoModality, v.Embedding)
+			emb.StartOffsetSec = v.StartOffsetSec
+			emb.EndOffsetSec = v.EndOffsetSec
+			embs = append(embs, emb)
+		}
+	}
+	return embs, nil
 }
 
-// MultiEmbeddings returns multimodal embeddings for every object in EmbeddingRequest.
-func (c *Client) MultiEmbeddings(ctx context.Context, embReq *MultiEmbeddingRequest) (*MultiEmbedddingResponse, error) {
-	u, err := url.Parse(c.opts.BaseURL + "/" + c.opts.ProjectID + "/" + ModelURI + "/" + c.opts.ModelID + EmbedAction)
+// ToEmbeddings converts the API response,
+// into a slice of embeddings and returns it.
+// The embeddings are ordered the same way as in ToMultiEmbeddings.
+func (e *MultiEmbedddingResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	multiEmbs, err := e.ToMultiEmbeddings()
 	if err != nil {
 		return nil, err
 	}
-
-	var body = &bytes.Buffer{}
-	enc := json.NewEncoder(body)
-	enc.SetEscapeHTML(false)
-	if err := enc.Encode(embReq); err != nil {
-		return nil, err
+	embs := make([]*embeddings.Embedding, 0, len(multiEmbs))
+	for _, emb := range multiEmbs {
+		embs = append(embs, emb.Embedding)
 	}
+	return embs, nil
+}
 
-	if c.opts.Token == "" {
-		var err error
-		c.opts.Token, err = GetToken(c.opts.TokenSrc)
-		if err != nil {
-			return nil, err
-		}
-	}
+// MultiPrediction for a given request.
+type MultiPrediction struct {
+	Image []float64        `json:"imageEmbedding,omitempty"`
+	Text  []float64        `json:"textEmbedding,omitempty"`
+	Video []VideoEmbedding `json:"videoEmbeddings,omitempty"`
+}
 
-	options := []request.Option{
-		request.WithBearer(c.opts.Token),
-	}
+// VideoEmbedding is the embedding of a video segment.
+type VideoEmbedding struct {
+	StartOffsetSec int       `json:"startOffsetSec"`
+	EndOffsetSec   int       `json:"endOffsetSec"`
+	Embedding      []float64 `json:"embedding"`
+}
 
-	req, err := request.NewHTTP(ctx, http.MethodPost, u.String(), body, options...)
-	if err != nil {
-		return nil, err
+// Modality is an embedding modality.
+type Modality string
+
+const (
+	TextModality  Modality = "text"
+	ImageModality Modality = "image"
+	VideoModality Modality = "video"
+)
+
+// String implements stringer.
+func (m Modality) String() string {
+	return string(m)
+}
+
+// MultiEmbedding is a multimodal embedding.
+type MultiEmbedding struct {
+	*embeddings.Embedding
+	// Instance is the index of the request instance.
+	Instance int `json:"instance"`
+	// Modality of the embedded content.
+	Modality Modality `json:"modality"`
+	// StartOffsetSec and EndOffsetSec are
+	// only set for the video segment embeddings.
+	StartOffsetSec int `json:"startOffsetSec,omitempty"`
+	EndOffsetSec   int `json:"endOffsetSec,omitempty"`
+}
+
+func newMultiEmbedding(instance int, modality Modality, vals []float64) *MultiEmbedding {
+	floats := make([]float64, len(vals))
+	copy(floats, vals)
+	return &MultiEmbedding{
+		Embedding: &embeddings.Embedding{
+			Vector: floats,
+		},
+		Instance: instance,
+		Modality: modality,
 	}
+}
 
-	resp, err := request.Do[APIError](c.opts.HTTPClient, req)
+// MultiEmbeddings returns multimodal embeddings for every object in EmbeddingRequest.
+func (c *Client) MultiEmbeddings(ctx context.Context, embReq *MultiEmbeddingRequest) (*MultiEmbedddingResponse, error) {
+	u, err := c.predictURL()
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	e := new(MultiEmbedddingResponse)
-	if err := json.NewDecoder(resp.Body).Decode(e); err != nil {
+	if err := c.post(ctx, u.String(), embReq, e); err != nil {
 		return nil, err
 	}
 
 	return e, nil
 }
+
+// MultiEmbed returns multimodal embeddings for every object in EmbeddingRequest.
+func (c *Client) MultiEmbed(ctx context.Context, embReq *MultiEmbeddingRequest) ([]*embeddings.Embedding, error) {
+	e, err := c.MultiEmbeddings(ctx, embReq)
+	if err != nil {
+		return nil, err
+	}
+	return e.ToEmbeddings()
+}
+
+// multiEmbedder adapts Client to embeddings.Embedder.
+type multiEmbedder struct {
+	*Client
+}
+
+// Embed implements embeddings.Embedder.
+func (m multiEmbedder) Embed(ctx context.Context, embReq *MultiEmbeddingRequest) ([]*embeddings.Embedding, error) {
+	return m.MultiEmbed(ctx, embReq)
+}
+
+// NewMultiEmbedder creates a client that implements embeddings.Embedder
+// for the multimodal embeddings models.
+func NewMultiEmbedder(opts ...Option) embeddings.Embedder[*MultiEmbeddingRequest] {
+	return multiEmbedder{NewClient(opts...)}
+}
@@ -0,0 +1,102 @@
+package vertexai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiEmbed(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := map[string]any{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, req["instances"], []any{
+			map[string]any{
+				"text":  "foo",
+				"image": map[string]any{"bytesBase64Encoded": "Zm9v"},
+			},
+			map[string]any{
+				"video": map[string]any{
+					"gcsUri": "gs://foo/bar.mp4",
+					"videoSegmentConfig": map[string]any{
+						"startOffsetSec": float64(0),
+						"endOffsetSec":   float64(20),
+						"intervalSec":    float64(10),
+					},
+				},
+			},
+		})
+
+		_, _ = w.Write([]byte(`{
+			"predictions": [
+				{"textEmbedding": [1.0], "imageEmbedding": [2.0]},
+				{"videoEmbeddings": [
+					{"startOffsetSec": 0, "endOffsetSec": 10, "embedding": [3.0]},
+					{"startOffsetSec": 10, "endOffsetSec": 20, "embedding": [4.0]}
+				]}
+			],
+			"deployedModelId": "123"
+		}`))
+	}))
+	defer srv.Close()
+
+	text, start := "foo", 0
+	embReq := &MultiEmbeddingRequest{
+		Instances: []MultiInstance{
+			{
+				Text:  &text,
+				Image: NewImageBytes([]byte("foo")),
+			},
+			{
+				Video: NewVideoGCS("gs://foo/bar.mp4", &VideoSegmentConfig{
+					StartOffsetSec: &start,
+					EndOffsetSec:   20,
+					IntervalSec:    10,
+				}),
+			},
+		},
+	}
+
+	c := NewClient(
+		WithToken(vertexaiToken),
+		WithModelID(EmbedMultiGecko.String()),
+		WithBaseURL(srv.URL),
+	)
+
+	resp, err := c.MultiEmbeddings(context.Background(), embReq)
+	assert.NoError(t, err)
+
+	multiEmbs, err := resp.ToMultiEmbeddings()
+	assert.NoError(t, err)
+	assert.Len(t, multiEmbs, 4)
+	assert.Equal(t, multiEmbs[0].Modality, TextModality)
+	assert.Equal(t, multiEmbs[1].Modality, ImageModality)
+	assert.Equal(t, multiEmbs[2].Modality, VideoModality)
+	assert.Equal(t, multiEmbs[3].Instance, 1)
+	assert.Equal(t, multiEmbs[3].StartOffsetSec, 10)
+	assert.Equal(t, multiEmbs[3].EndOffsetSec, 20)
+
+	embs, err := NewMultiEmbedder(
+		WithToken(vertexaiToken),
+		WithModelID(EmbedMultiGecko.String()),
+		WithBaseURL(srv.URL),
+	).Embed(context.Background(), embReq)
+	assert.NoError(t, err)
+	assert.Len(t, embs, 4)
+	for i, emb := range embs {
+		assert.Equal(t, emb.Vector, []float64{float64(i + 1)})
+	}
+}
+
+func TestDeprecatedImages(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ImageGCS{URI: "gs://foo/bar.png"}.Image(), NewImageGCS("gs://foo/bar.png"))
+	assert.Equal(t, ImageBase64{Bytes: "Zm9v"}.Image(), NewImageBytes([]byte("foo")))
+}
@@ -1,9 +1,12 @@
 package vertexai
 
 import (
+	"context"
 	"errors"
+	"sync"
 
 	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
 )
 
 var (
@@ -29,3 +32,112 @@ func GetToken(tokenSrc oauth2.TokenSource) (string, error) {
 
 	return "", ErrMissingTokenSource
 }
+
+// NewDefaultTokenSource creates a new token source from the Google
+// Application Default Credentials and returns it. If no scopes are
+// given the token source requests Scopes. The returned token source
+// does not reuse tokens: it fetches a new token on every call,
+// so the client can refresh the tokens rejected by the API.
+// https://cloud.google.com/docs/authentication/application-default-credentials
+func NewDefaultTokenSource(ctx context.Context, scopes ...string) (oauth2.TokenSource, error) {
+	if len(scopes) == 0 {
+		scopes = []string{Scopes}
+	}
+	newSrc := func() (oauth2.TokenSource, error) {
+		creds, err := google.FindDefaultCredentials(ctx, scopes...)
+		if err != nil {
+			return nil, err
+		}
+		return creds.TokenSource, nil
+	}
+	if _, err := newSrc(); err != nil {
+		return nil, err
+	}
+	return freshTokenSource{newSrc: newSrc}, nil
+}
+
+// freshTokenSource is a token source which never reuses tokens.
+// The Google credentials token sources cache the tokens in
+// oauth2.ReuseTokenSource which can't be forced to fetch a new
+// token, so a new token source is created for every token.
+type freshTokenSource struct {
+	newSrc func() (oauth2.TokenSource, error)
+}
+
+// Token implements oauth2.TokenSource.
+func (f freshTokenSource) Token() (*oauth2.Token, error) {
+	ts, err := f.newSrc()
+	if err != nil {
+		return nil, err
+	}
+	return ts.Token()
+}
+
+// tokenSource is a thread-safe oauth2.TokenSource.
+// It reuses the token fetched from the underlying
+// source until it expires or until it's invalidated.
+type tokenSource struct {
+	mu  sync.Mutex
+	src oauth2.TokenSource
+	tok *oauth2.Token
+	// static is true if the token can not be refreshed.
+	static bool
+}
+
+// newTokenSource creates a new token source and returns it.
+// If token is not empty it's used as a static token.
+// Otherwise the tokens are fetched from src.
+func newTokenSource(token string, src oauth2.TokenSource) *tokenSource {
+	if token != "" {
+		return &tokenSource{
+			tok:    &oauth2.Token{AccessToken: token},
+			static: true,
+		}
+	}
+	return &tokenSource{
+		src: src,
+	}
+}
+
+// Token returns a valid token. It only fetches
+// a new token from the underlying token source
+// if the reused token has expired.
+func (t *tokenSource) Token() (*oauth2.Token, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.tok.Valid() {
+		return t.tok, nil
+	}
+	if t.src == nil {
+		return nil, ErrMissingTokenSource
+	}
+
+	tok, err := t.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	t.tok = tok
+
+	return tok, nil
+}
+
+// invalidate discards the reused token if it matches tok
+// so that the next call to Token fetches a new token.
+// It returns false if the token can not be refreshed.
+func (t *tokenSource) invalidate(tok *oauth2.Token) bool {
+	if t.static {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// NOTE: the token might have already been
+	// refreshed by another concurrent request.
+	if t.tok == tok {
+		t.tok = nil
+	}
+
+	return true
+}
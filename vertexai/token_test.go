@@ -0,0 +1,171 @@
+package vertexai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+// countingTS issues a new token on every call.
+type countingTS struct {
+	calls  atomic.Int32
+	expiry time.Duration
+}
+
+func (c *countingTS) Token() (*oauth2.Token, error) {
+	n := c.calls.Add(1)
+	return &oauth2.Token{
+		AccessToken: fmt.Sprintf("token-%d", n),
+		Expiry:      time.Now().Add(c.expiry),
+	}, nil
+}
+
+func TestTokenSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+		_, err := newTokenSource("", nil).Token()
+		assert.ErrorIs(t, err, ErrMissingTokenSource)
+	})
+
+	t.Run("static", func(t *testing.T) {
+		t.Parallel()
+		ts := newTokenSource(vertexaiToken, &countingTS{expiry: time.Hour})
+		tok, err := ts.Token()
+		assert.NoError(t, err)
+		assert.Equal(t, tok.AccessToken, vertexaiToken)
+		assert.False(t, ts.invalidate(tok))
+	})
+
+	t.Run("reuse", func(t *testing.T) {
+		t.Parallel()
+		src := &countingTS{expiry: time.Hour}
+		ts := newTokenSource("", src)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				tok, err := ts.Token()
+				assert.NoError(t, err)
+				assert.Equal(t, tok.AccessToken, "token-1")
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, src.calls.Load(), int32(1))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+		src := &countingTS{expiry: -time.Hour}
+		ts := newTokenSource("", src)
+
+		for i := 1; i <= 2; i++ {
+			tok, err := ts.Token()
+			assert.NoError(t, err)
+			assert.Equal(t, tok.AccessToken, fmt.Sprintf("token-%d", i))
+		}
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		t.Parallel()
+		src := &countingTS{expiry: time.Hour}
+		ts := newTokenSource("", src)
+
+		stale, err := ts.Token()
+		assert.NoError(t, err)
+		assert.True(t, ts.invalidate(stale))
+
+		tok, err := ts.Token()
+		assert.NoError(t, err)
+		assert.Equal(t, tok.AccessToken, "token-2")
+
+		// stale token must not invalidate the refreshed one
+		assert.True(t, ts.invalidate(stale))
+		tok, err = ts.Token()
+		assert.NoError(t, err)
+		assert.Equal(t, tok.AccessToken, "token-2")
+	})
+}
+
+func TestUnauthorizedRefresh(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":401,"message":"invalid credentials","status":"UNAUTHENTICATED"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"predictions":[{"embeddings":{"values":[1.0]}}]}`))
+	}))
+	defer srv.Close()
+
+	src := &countingTS{expiry: time.Hour}
+	c := NewClient(
+		WithToken(""),
+		WithTokenSrc(src),
+		WithBaseURL(srv.URL),
+	)
+
+	embs, err := c.Embed(context.Background(), &EmbeddingRequest{
+		Instances: []Instance{{Content: "foo"}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 1)
+	assert.Equal(t, src.calls.Load(), int32(2))
+	assert.Empty(t, c.opts.Token)
+
+	c = NewClient(
+		WithToken("foo"),
+		WithBaseURL(srv.URL),
+	)
+	_, err = c.Embed(context.Background(), &EmbeddingRequest{
+		Instances: []Instance{{Content: "foo"}},
+	})
+	assert.ErrorAs(t, err, &APIError{})
+}
+
+func TestDefaultTokenSourceRefresh(t *testing.T) {
+	var calls atomic.Int32
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+	defer tokenSrv.Close()
+
+	path := filepath.Join(t.TempDir(), "key.json")
+	assert.NoError(t, os.WriteFile(path, serviceAccountKey(t, tokenSrv.URL), 0600))
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+
+	ts, err := NewDefaultTokenSource(context.Background())
+	assert.NoError(t, err)
+
+	// NOTE: google.DefaultTokenSource would keep returning
+	// token-1 because it caches the token until it expires.
+	src := newTokenSource("", ts)
+	tok, err := src.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", tok.AccessToken)
+	assert.True(t, src.invalidate(tok))
+	tok, err = src.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", tok.AccessToken)
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", filepath.Join(t.TempDir(), "missing.json"))
+	_, err = NewDefaultTokenSource(context.Background())
+	assert.Error(t, err)
+}
@@ -15,13 +15,58 @@ const (
 	// EmbedMultiGecko is a multilanguage embeddings model
 	EmbedMultiGecko     Model = "multimodalembedding@001"
 	EmbedMultiPreviewV4 Model = "text-multilingual-embedding-preview-0409"
+	// EmbedTextV* are English and code embedding models
+	// that support setting the output dimensionality.
+	EmbedTextV4 Model = "text-embedding-004"
+	EmbedTextV5 Model = "text-embedding-005"
+	// EmbedMultiLingV2 is a multilingual embeddings model.
+	EmbedMultiLingV2 Model = "text-multilingual-embedding-002"
+	// EmbedGemini is a Gemini embeddings model.
+	// It supports English, multilingual and code tasks.
+	EmbedGemini    Model = "gemini-embedding-001"
+	EmbedGeminiExp Model = "text-embedding-large-exp-03-07"
 )
 
+// Limits are model request limits.
+// https://cloud.google.com/vertex-ai/generative-ai/docs/embeddings/get-text-embeddings
+type Limits struct {
+	// MaxInstances is the maximum number of instances per request.
+	MaxInstances int
+	// MaxTokens is the maximum number of input tokens per instance.
+	// Longer inputs are truncated unless AutoTruncate is disabled.
+	MaxTokens int
+	// MinDims and MaxDims is the range of allowed output dimensionality.
+	// MaxDims is zero if the model does not support setting it.
+	MinDims int
+	MaxDims int
+}
+
+// modelLimits are the known limits of the text embedding models.
+var modelLimits = map[Model]Limits{
+	EmbedGeckoV1:        {MaxInstances: 5, MaxTokens: 3072},
+	EmbedGeckoV2:        {MaxInstances: 5, MaxTokens: 3072},
+	EmbedGeckoV3:        {MaxInstances: 250, MaxTokens: 3072},
+	EmbedPreviewV4:      {MaxInstances: 250, MaxTokens: 2048, MinDims: 1, MaxDims: 768},
+	EmbedMultiPreviewV4: {MaxInstances: 250, MaxTokens: 2048, MinDims: 1, MaxDims: 768},
+	EmbedTextV4:         {MaxInstances: 250, MaxTokens: 2048, MinDims: 1, MaxDims: 768},
+	EmbedTextV5:         {MaxInstances: 250, MaxTokens: 2048, MinDims: 1, MaxDims: 768},
+	EmbedMultiLingV2:    {MaxInstances: 250, MaxTokens: 2048, MinDims: 1, MaxDims: 768},
+	EmbedGemini:         {MaxInstances: 1, MaxTokens: 2048, MinDims: 1, MaxDims: 3072},
+	EmbedGeminiExp:      {MaxInstances: 1, MaxTokens: 8192, MinDims: 1, MaxDims: 3072},
+}
+
 // String implements stringer.
 func (m Model) String() string {
 	return string(m)
 }
 
+// Limits returns the request limits of the text embedding model.
+// It returns false if the model limits are not known.
+func (m Model) Limits() (Limits, bool) {
+	limits, ok := modelLimits[m]
+	return limits, ok
+}
+
 // TaskType is embedding task type.
 // It can be used to improve the embedding quality
 // when targeting a specific task.
@@ -34,6 +79,9 @@ const (
 	SemanticSimTask    TaskType = "SEMANTIC_SIMILARITY"
 	ClassificationTask TaskType = "CLASSIFICATION"
 	ClusteringTask     TaskType = "CLUSTERING"
+	QATask             TaskType = "QUESTION_ANSWERING"
+	FactVerifTask      TaskType = "FACT_VERIFICATION"
+	CodeRetrQueryTask  TaskType = "CODE_RETRIEVAL_QUERY"
 )
 
 // String implements stringer.
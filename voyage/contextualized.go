@@ -0,0 +1,206 @@
+package voyage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/milosgajdos/go-embeddings"
+)
+
+// SplitDocs splits every document in docs into chunks
+// using the given splitter and returns the chunks grouped
+// per document so they can be used as contextualized embedding inputs.
+func SplitDocs(s embeddings.TextSplitter, docs ...string) [][]string {
+	inputs := make([][]string, 0, len(docs))
+	for _, doc := range docs {
+		inputs = append(inputs, s.Split(doc))
+	}
+	return inputs
+}
+
+// ContextualizedEmbeddingRequest sent to contextualized chunk embeddings API endpoint.
+// Every input is a document represented as a list of its chunks.
+// https://docs.voyageai.com/reference/contextualized-chunk-embeddings-api
+type ContextualizedEmbeddingRequest struct {
+	Inputs          [][]string     `json:"inputs"`
+	Model           Model          `json:"model"`
+	InputType       InputType      `json:"input_type,omitempty"`
+	OutputDimension int            `json:"output_dimension,omitempty"`
+	OutputDType     OutputDType    `json:"output_dtype,omitempty"`
+	EncodingFormat  EncodingFormat `json:"output_encoding,omitempty"`
+}
+
+// ContextualizedData stores chunk embeddings of a single document.
+type ContextualizedData struct {
+	Object string `json:"object"`
+	Index  int    `json:"index"`
+	Data   []Data `json:"data"`
+}
+
+// ContextualizedEmbeddingResponse is the contextualized chunk embeddings API response.
+type ContextualizedEmbeddingResponse struct {
+	Object string               `json:"object"`
+	Data   []ContextualizedData `json:"data"`
+	Model  Model                `json:"model"`
+	Usage  Usage                `json:"usage"`
+}
+
+// ToEmbeddings converts the API response,
+// into a flat slice of embeddings and returns it.
+// The embeddings are ordered by document and then by chunk.
+func (e *ContextualizedEmbeddingResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
+	docs, err := e.ToChunkEmbeddings()
+	if err != nil {
+		return nil, err
+	}
+	n := 0
+	for _, chunks := range docs {
+		n += len(chunks)
+	}
+	embs := make([]*embeddings.Embedding, 0, n)
+	for _, chunks := range docs {
+		embs = append(embs, chunks...)
+	}
+	return embs, nil
+}
+
+// ToChunkEmbeddings converts the API response into chunk embeddings
+// grouped per document and returns them. The returned slice is
+// aligned with the request inputs i.e. embs[i][j] is the embedding
+// of the j-th chunk of the i-th input document.
+func (e *ContextualizedEmbeddingResponse) ToChunkEmbeddings() ([][]*embeddings.Embedding, error) {
+	docs := make([]ContextualizedData, len(e.Data))
+	copy(docs, e.Data)
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].Index < docs[j].Index })
+
+	embs := make([][]*embeddings.Embedding, 0, len(docs))
+	for _, doc := range docs {
+		chunks := make([]Data, len(doc.Data))
+		copy(chunks, doc.Data)
+		sort.SliceStable(chunks, func(i, j int) bool { return chunks[i].Index < chunks[j].Index })
+
+		docEmbs := make([]*embeddings.Embedding, 0, len(chunks))
+		for _, d := range chunks {
+			floats := make([]float64, len(d.Embedding))
+			copy(floats, d.Embedding)
+			docEmbs = append(docEmbs, &embeddings.Embedding{
+				Vector: floats,
+			})
+		}
+		embs = append(embs, docEmbs)
+	}
+	return embs, nil
+}
+
+// ContextualizedDataGen is a generic struct used for deserializing document chunk embeddings.
+type ContextualizedDataGen[T any] struct {
+	Object string       `json:"object"`
+	Index  int          `json:"index"`
+	Data   []DataGen[T] `json:"data"`
+}
+
+// ContextualizedEmbeddingResponseGen is a generic struct used for deserializing API response.
+type ContextualizedEmbeddingResponseGen[T any] struct {
+	Object string                     `json:"object"`
+	Data   []ContextualizedDataGen[T] `json:"data"`
+	Model  Model                      `json:"model"`
+	Usage  Usage                      `json:"usage"`
+}
+
+// toContextualizedResp decodes the raw API response,
+// parses it into contextualized embedding response and returns it.
+func toContextualizedResp[T any](resp io.Reader) (*ContextualizedEmbeddingResponse, error) {
+	data := new(ContextualizedEmbeddingResponseGen[T])
+	if err := json.NewDecoder(resp).Decode(data); err != nil {
+		return nil, err
+	}
+
+	docs := make([]ContextualizedData, 0, len(data.Data))
+	for _, doc := range data.Data {
+		chunks := make([]Data, 0, len(doc.Data))
+		for _, d := range doc.Data {
+			var floats []float64
+			switch emb := any(d.Embedding).(type) {
+			case embeddings.Base64:
+				e, err := emb.DecodeFloat32()
+				if err != nil {
+					return nil, err
+				}
+				floats = e.Vector
+			case []float64:
+				floats = emb
+			default:
+				return nil, ErrInValidData
+			}
+			chunks = append(chunks, Data{
+				Object:    d.Object,
+				Index:     d.Index,
+				Embedding: floats,
+			})
+		}
+		docs = append(docs, ContextualizedData{
+			Object: doc.Object,
+			Index:  doc.Index,
+			Data:   chunks,
+		})
+	}
+
+	return &ContextualizedEmbeddingResponse{
+		Object: data.Object,
+		Data:   docs,
+		Model:  data.Model,
+		Usage:  data.Usage,
+	}, nil
+}
+
+// ContextualizedEmbed returns contextualized chunk embeddings for every
+// document in ContextualizedEmbeddingRequest. The returned embeddings are
+// grouped per document and aligned with the request inputs.
+// NOTE: base64 encoding is only supported with the float output data type.
+func (c *Client) ContextualizedEmbed(ctx context.Context, embReq *ContextualizedEmbeddingRequest) ([][]*embeddings.Embedding, error) {
+	if embReq.EncodingFormat == EncodingBase64 &&
+		embReq.OutputDType != "" && embReq.OutputDType != FloatDType {
+		return nil, fmt.Errorf("%w: %s with %s encoding", ErrUnsupportedDType, embReq.OutputDType, embReq.EncodingFormat)
+	}
+
+	resp, err := c.post(ctx, "/contextualizedembeddings", embReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var embs *ContextualizedEmbeddingResponse
+
+	switch embReq.EncodingFormat {
+	case EncodingBase64:
+		embs, err = toContextualizedResp[embeddings.Base64](resp.Body)
+	case EncodingNone, "":
+		embs, err = toContextualizedResp[[]float64](resp.Body)
+	default:
+		return nil, ErrUnsupportedEncoding
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := embs.ToChunkEmbeddings()
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: the embeddings can only be aligned with
+	// the inputs if every input has been embedded.
+	if len(docs) != len(embReq.Inputs) {
+		return nil, fmt.Errorf("%w: %d documents, expected %d", ErrInValidData, len(docs), len(embReq.Inputs))
+	}
+	for i, chunks := range docs {
+		if len(chunks) != len(embReq.Inputs[i]) {
+			return nil, fmt.Errorf("%w: %d chunks in document %d, expected %d", ErrInValidData, len(chunks), i, len(embReq.Inputs[i]))
+		}
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,108 @@
+package voyage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/milosgajdos/go-embeddings/document/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitDocs(t *testing.T) {
+	t.Parallel()
+
+	s := text.NewSplitter().
+		WithChunkSize(3).
+		WithChunkOverlap(0)
+	rs := text.NewRecursiveCharSplitter().
+		WithSplitter(s)
+
+	inputs := SplitDocs(rs, "foo bar", "baz")
+	assert.Equal(t, inputs, [][]string{{"foo", "bar"}, {"baz"}})
+}
+
+func TestContextualizedEmbed(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1/contextualizedembeddings")
+
+		req := new(ContextualizedEmbeddingRequest)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		assert.Equal(t, req.Inputs, [][]string{{"foo", "bar"}, {"baz"}})
+
+		// NOTE: the results are deliberately out of order
+		_, _ = w.Write([]byte(`{
+			"object": "list",
+			"data": [
+				{"object": "list", "index": 1, "data": [
+					{"object": "embedding", "index": 0, "embedding": [3.0]}
+				]},
+				{"object": "list", "index": 0, "data": [
+					{"object": "embedding", "index": 1, "embedding": [2.0]},
+					{"object": "embedding", "index": 0, "embedding": [1.0]}
+				]}
+			],
+			"model": "voyage-context-3",
+			"usage": {"total_tokens": 3}
+		}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithAPIKey(voyageAPIKey), WithBaseURL(srv.URL))
+	embs, err := c.ContextualizedEmbed(context.Background(), &ContextualizedEmbeddingRequest{
+		Inputs: [][]string{{"foo", "bar"}, {"baz"}},
+		Model:  ContextV3,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 2)
+	assert.Len(t, embs[0], 2)
+	assert.Len(t, embs[1], 1)
+	assert.Equal(t, embs[0][0].Vector, []float64{1.0})
+	assert.Equal(t, embs[0][1].Vector, []float64{2.0})
+	assert.Equal(t, embs[1][0].Vector, []float64{3.0})
+
+	mismatchSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"object": "list",
+			"data": [
+				{"object": "list", "index": 0, "data": [
+					{"object": "embedding", "index": 0, "embedding": [1.0]},
+					{"object": "embedding", "index": 1, "embedding": [2.0]}
+				]},
+				{"object": "list", "index": 1, "data": [
+					{"object": "embedding", "index": 0, "embedding": [3.0]}
+				]}
+			],
+			"model": "voyage-context-3",
+			"usage": {"total_tokens": 3}
+		}`))
+	}))
+	defer mismatchSrv.Close()
+
+	c = NewClient(WithAPIKey(voyageAPIKey), WithBaseURL(mismatchSrv.URL))
+	_, err = c.ContextualizedEmbed(context.Background(), &ContextualizedEmbeddingRequest{
+		Inputs: [][]string{{"foo", "bar"}, {"baz"}, {"qux"}},
+		Model:  ContextV3,
+	})
+	assert.ErrorIs(t, err, ErrInValidData)
+
+	_, err = c.ContextualizedEmbed(context.Background(), &ContextualizedEmbeddingRequest{
+		Inputs: [][]string{{"foo", "bar", "baz"}, {"qux"}},
+		Model:  ContextV3,
+	})
+	assert.ErrorIs(t, err, ErrInValidData)
+
+	for _, dtype := range []OutputDType{Int8DType, Uint8DType, BinaryDType, UbinaryDType} {
+		_, err = c.ContextualizedEmbed(context.Background(), &ContextualizedEmbeddingRequest{
+			Inputs:         [][]string{{"foo", "bar"}, {"baz"}},
+			Model:          ContextV3,
+			OutputDType:    dtype,
+			EncodingFormat: EncodingBase64,
+		})
+		assert.ErrorIs(t, err, ErrUnsupportedDType)
+	}
+}
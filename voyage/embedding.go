@@ -52,7 +52,11 @@ func (e *EmbeddingResponse) ToEmbeddings() ([]*embeddings.Embedding, error) {
 }
 
 // Usage tracks API token usage.
+// NOTE: TextTokens and ImagePixels are only
+// reported by the multimodal embeddings API.
 type Usage struct {
+	TextTokens  int `json:"text_tokens,omitempty"`
+	ImagePixels int `json:"image_pixels,omitempty"`
 	TotalTokens int `json:"total_tokens"`
 }
 
@@ -83,7 +87,7 @@ func toEmbeddingResp[T any](resp io.Reader) (*EmbeddingResponse, error) {
 	case *EmbeddingResponseGen[embeddings.Base64]:
 		embData := make([]Data, 0, len(e.Data))
 		for _, d := range e.Data {
-			emb, err := d.Embedding.Decode()
+			emb, err := d.Embedding.DecodeFloat32()
 			if err != nil {
 				return nil, err
 			}
@@ -117,7 +121,24 @@ func toEmbeddingResp[T any](resp io.Reader) (*EmbeddingResponse, error) {
 
 // Embed returns embeddings for every object in EmbeddingRequest.
 func (c *Client) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embeddings.Embedding, error) {
-	u, err := url.Parse(c.opts.BaseURL + "/" + c.opts.Version + "/embeddings")
+	resp, err := c.post(ctx, "/embeddings", embReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	embs, err := decodeEmbeddingResp(resp.Body, embReq.EncodingFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	return embs.ToEmbeddings()
+}
+
+// post encodes the payload to JSON and sends it
+// to the given API endpoint returning the response.
+func (c *Client) post(ctx context.Context, endpoint string, payload any) (*http.Response, error) {
+	u, err := url.Parse(c.opts.BaseURL + "/" + c.opts.Version + endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +146,7 @@ func (c *Client) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embedd
 	var body = &bytes.Buffer{}
 	enc := json.NewEncoder(body)
 	enc.SetEscapeHTML(false)
-	if err := enc.Encode(embReq); err != nil {
+	if err := enc.Encode(payload); err != nil {
 		return nil, err
 	}
 
@@ -138,25 +159,17 @@ func (c *Client) Embed(ctx context.Context, embReq *EmbeddingRequest) ([]*embedd
 		return nil, err
 	}
 
-	resp, err := request.Do[APIError](c.opts.HTTPClient, req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var embs *EmbeddingResponse
+	return request.Do[APIError](c.opts.HTTPClient, req)
+}
 
-	switch embReq.EncodingFormat {
+// decodeEmbeddingResp decodes the API response
+// encoded in the given encoding format.
+func decodeEmbeddingResp(r io.Reader, format EncodingFormat) (*EmbeddingResponse, error) {
+	switch format {
 	case EncodingBase64:
-		embs, err = toEmbeddingResp[EmbeddingResponseGen[embeddings.Base64]](resp.Body)
+		return toEmbeddingResp[EmbeddingResponseGen[embeddings.Base64]](r)
 	case EncodingNone, "":
-		embs, err = toEmbeddingResp[EmbeddingResponseGen[[]float64]](resp.Body)
-	default:
-		return nil, ErrUnsupportedEncoding
+		return toEmbeddingResp[EmbeddingResponseGen[[]float64]](r)
 	}
-	if err != nil {
-		return nil, err
-	}
-
-	return embs.ToEmbeddings()
+	return nil, ErrUnsupportedEncoding
 }
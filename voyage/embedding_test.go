@@ -0,0 +1,42 @@
+package voyage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1/embeddings")
+
+		req := new(EmbeddingRequest)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		switch req.EncodingFormat {
+		case EncodingBase64:
+			// NOTE: base64 embeddings are little-endian float32 values
+			_, _ = w.Write([]byte(`{"object":"list","data":[{"object":"embedding","index":0,"embedding":"AACAPwAAAEA="}],"model":"voyage-3","usage":{"total_tokens":1}}`))
+		default:
+			_, _ = w.Write([]byte(`{"object":"list","data":[{"object":"embedding","index":0,"embedding":[1.0,2.0]}],"model":"voyage-3","usage":{"total_tokens":1}}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithAPIKey(voyageAPIKey), WithBaseURL(srv.URL))
+	for _, enc := range []EncodingFormat{"", EncodingBase64} {
+		embs, err := c.Embed(context.Background(), &EmbeddingRequest{
+			Input:          []string{"foo"},
+			Model:          VoyageV2,
+			EncodingFormat: enc,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, embs, 1)
+		assert.Equal(t, embs[0].Vector, []float64{1.0, 2.0}, enc)
+	}
+}
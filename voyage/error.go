@@ -10,6 +10,10 @@ var (
 	ErrInValidData = errors.New("invalid data")
 	// ErrUnsupportedEncoding is returned when API client attempts to use unsupported encoding format.
 	ErrUnsupportedEncoding = errors.New("unsupported encoding format")
+	// ErrUnsupportedDType is returned when API client attempts to use unsupported output data type.
+	ErrUnsupportedDType = errors.New("unsupported output data type")
+	// ErrUnsupportedImage is returned when the image content is not a supported image format.
+	ErrUnsupportedImage = errors.New("unsupported image format")
 )
 
 // APIError is Cohere API error.
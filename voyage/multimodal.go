@@ -0,0 +1,154 @@
+package voyage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"net/http"
+	"os"
+
+	"github.com/milosgajdos/go-embeddings"
+)
+
+// ContentType is a multimodal content type.
+type ContentType string
+
+const (
+	TextContent        ContentType = "text"
+	ImageURLContent    ContentType = "image_url"
+	ImageBase64Content ContentType = "image_base64"
+)
+
+// String implements stringer.
+func (c ContentType) String() string {
+	return string(c)
+}
+
+// Content is a single piece of multimodal input content.
+// Only the field matching the Type should be set.
+type Content struct {
+	Type        ContentType `json:"type"`
+	Text        string      `json:"text,omitempty"`
+	ImageURL    string      `json:"image_url,omitempty"`
+	ImageBase64 string      `json:"image_base64,omitempty"`
+}
+
+// NewTextContent creates text content and returns it.
+func NewTextContent(text string) Content {
+	return Content{
+		Type: TextContent,
+		Text: text,
+	}
+}
+
+// NewImageURLContent creates image content
+// referencing the image at the given URL and returns it.
+func NewImageURLContent(url string) Content {
+	return Content{
+		Type:     ImageURLContent,
+		ImageURL: url,
+	}
+}
+
+// NewImageBase64Content creates image content from raw image data
+// encoded as base64 data URL and returns it.
+// If mediaType is empty it is detected from data.
+func NewImageBase64Content(mediaType string, data []byte) Content {
+	if mediaType == "" {
+		mediaType = http.DetectContentType(data)
+	}
+	return Content{
+		Type:        ImageBase64Content,
+		ImageBase64: "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data),
+	}
+}
+
+// NewImageFileContent reads the image file at the given path
+// and returns it as base64 encoded image content.
+func NewImageFileContent(path string) (Content, error) {
+	// nolint:gosec
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Content{}, err
+	}
+	mediaType := http.DetectContentType(data)
+	if !isImageMediaType(mediaType) {
+		return Content{}, ErrUnsupportedImage
+	}
+	return NewImageBase64Content(mediaType, data), nil
+}
+
+// NewImageContent encodes img as PNG and returns
+// it as base64 encoded image content.
+func NewImageContent(img image.Image) (Content, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return Content{}, err
+	}
+	return NewImageBase64Content("image/png", buf.Bytes()), nil
+}
+
+// MultimodalInput is a single multimodal input
+// made of interleaved text and image content.
+type MultimodalInput struct {
+	Content []Content `json:"content"`
+}
+
+// NewMultimodalInput creates a new multimodal input and returns it.
+func NewMultimodalInput(content ...Content) MultimodalInput {
+	return MultimodalInput{
+		Content: content,
+	}
+}
+
+// MultimodalEmbeddingRequest sent to multimodal embeddings API endpoint.
+// https://docs.voyageai.com/reference/multimodal-embeddings-api
+type MultimodalEmbeddingRequest struct {
+	Inputs         []MultimodalInput `json:"inputs"`
+	Model          Model             `json:"model"`
+	InputType      InputType         `json:"input_type,omitempty"`
+	EncodingFormat EncodingFormat    `json:"output_encoding,omitempty"`
+	Truncation     bool              `json:"truncation,omitempty"`
+}
+
+// MultimodalEmbed returns embeddings for every input in MultimodalEmbeddingRequest.
+func (c *Client) MultimodalEmbed(ctx context.Context, embReq *MultimodalEmbeddingRequest) ([]*embeddings.Embedding, error) {
+	resp, err := c.post(ctx, "/multimodalembeddings", embReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	embs, err := decodeEmbeddingResp(resp.Body, embReq.EncodingFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	return embs.ToEmbeddings()
+}
+
+// multimodalEmbedder adapts Client to embeddings.Embedder.
+type multimodalEmbedder struct {
+	*Client
+}
+
+// Embed implements embeddings.Embedder.
+func (m multimodalEmbedder) Embed(ctx context.Context, embReq *MultimodalEmbeddingRequest) ([]*embeddings.Embedding, error) {
+	return m.MultimodalEmbed(ctx, embReq)
+}
+
+// NewMultimodalEmbedder creates a client that implements embeddings.Embedder
+// for the multimodal embeddings API.
+func NewMultimodalEmbedder(opts ...Option) embeddings.Embedder[*MultimodalEmbeddingRequest] {
+	return multimodalEmbedder{NewClient(opts...)}
+}
+
+func isImageMediaType(mediaType string) bool {
+	switch mediaType {
+	case "image/png", "image/jpeg", "image/gif", "image/webp":
+		return true
+	}
+	return false
+}
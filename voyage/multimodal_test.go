@@ -0,0 +1,101 @@
+package voyage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+		c := NewTextContent("foo")
+		assert.Equal(t, c.Type, TextContent)
+		assert.Equal(t, c.Text, "foo")
+	})
+
+	t.Run("image url", func(t *testing.T) {
+		t.Parallel()
+		c := NewImageURLContent("https://foo.com/bar.png")
+		assert.Equal(t, c.Type, ImageURLContent)
+		assert.Equal(t, c.ImageURL, "https://foo.com/bar.png")
+	})
+
+	t.Run("image", func(t *testing.T) {
+		t.Parallel()
+		img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+		img.Set(0, 0, color.White)
+		c, err := NewImageContent(img)
+		assert.NoError(t, err)
+		assert.Equal(t, c.Type, ImageBase64Content)
+		assert.True(t, strings.HasPrefix(c.ImageBase64, "data:image/png;base64,"))
+	})
+
+	t.Run("image file", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+
+		txt := filepath.Join(dir, "foo.txt")
+		assert.NoError(t, os.WriteFile(txt, []byte("foo"), 0600))
+		_, err := NewImageFileContent(txt)
+		assert.ErrorIs(t, err, ErrUnsupportedImage)
+
+		var buf bytes.Buffer
+		assert.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))))
+		pngFile := filepath.Join(dir, "foo.png")
+		assert.NoError(t, os.WriteFile(pngFile, buf.Bytes(), 0600))
+
+		c, err := NewImageFileContent(pngFile)
+		assert.NoError(t, err)
+		assert.Equal(t, c, NewImageBase64Content("image/png", buf.Bytes()))
+	})
+}
+
+func TestMultimodalEmbed(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1/multimodalembeddings")
+		assert.Equal(t, r.Header.Get("Authorization"), "Bearer "+voyageAPIKey)
+
+		req := new(MultimodalEmbeddingRequest)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		assert.Len(t, req.Inputs, 1)
+		assert.Len(t, req.Inputs[0].Content, 2)
+
+		_, _ = w.Write([]byte(`{
+			"object": "list",
+			"data": [{"object": "embedding", "index": 0, "embedding": [0.1, 0.2]}],
+			"model": "voyage-multimodal-3",
+			"usage": {"text_tokens": 2, "image_pixels": 4, "total_tokens": 3}
+		}`))
+	}))
+	defer srv.Close()
+
+	e := NewMultimodalEmbedder(WithAPIKey(voyageAPIKey), WithBaseURL(srv.URL))
+	embs, err := e.Embed(context.Background(), &MultimodalEmbeddingRequest{
+		Inputs: []MultimodalInput{
+			NewMultimodalInput(
+				NewTextContent("foo"),
+				NewImageURLContent("https://foo.com/bar.png"),
+			),
+		},
+		Model: MultimodalV3,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, embs, 1)
+	assert.Equal(t, embs[0].Vector, []float64{0.1, 0.2})
+}
@@ -8,6 +8,12 @@ const (
 	CodeV2         Model = "voyage-code-2"
 	VoyageV2       Model = "voyage-2"
 	LiteV2Instruct Model = "voyage-lite-02-instruct"
+	// MultimodalV3 is a multimodal embedding model.
+	// It can only be used with the multimodal embeddings API.
+	MultimodalV3 Model = "voyage-multimodal-3"
+	// ContextV3 is a contextualized chunk embedding model.
+	// It can only be used with the contextualized embeddings API.
+	ContextV3 Model = "voyage-context-3"
 )
 
 // String implements stringer.
@@ -43,3 +49,19 @@ const (
 func (f EncodingFormat) String() string {
 	return string(f)
 }
+
+// OutputDType is the data type of the returned embeddings.
+type OutputDType string
+
+const (
+	FloatDType   OutputDType = "float"
+	Int8DType    OutputDType = "int8"
+	Uint8DType   OutputDType = "uint8"
+	BinaryDType  OutputDType = "binary"
+	UbinaryDType OutputDType = "ubinary"
+)
+
+// String implements stringer.
+func (d OutputDType) String() string {
+	return string(d)
+}